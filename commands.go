@@ -0,0 +1,794 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+	"github.com/ebarkie/goblusb/internal/firmware"
+)
+
+// runFunc runs a command with its positional arguments.
+type runFunc func(ctx context.Context, args []string) error
+
+// command is a command or a group of subcommands.
+type command struct {
+	name    string
+	args    string // Positional arguments for usage
+	help    string
+	minArgs int
+	maxArgs int
+
+	// Supports structured output with -output
+	structured bool
+
+	// setup defines the command flags and returns the function that runs
+	// it once they're parsed.  Commands with subcommands don't have one.
+	setup func(fs *flag.FlagSet) runFunc
+	subs  []*command
+}
+
+var commands = []*command{
+	{name: "list", structured: true, help: "List attached controllers.", setup: listCmd},
+	{name: "version", structured: true, help: "Show the firmware version.", setup: versionCmd},
+	{name: "layers", help: "Get, set, or compare layers.", subs: []*command{
+		{name: "get", structured: true, help: "Get layers.  They're drawn on the keyboard with -layout.", setup: layersGetCmd},
+		{name: "set", args: "FILE", help: "Set layers from a CSV, keymap, JSON, YAML, or QMK keymap.json file.", minArgs: 1, maxArgs: 1, setup: layersSetCmd},
+		{name: "diff", args: "FILE", help: "Show what setting layers from a file would change.", minArgs: 1, maxArgs: 1, setup: layersDiffCmd},
+	}},
+	{name: "macros", help: "Get, set, or compare macro keys.", subs: []*command{
+		{name: "get", structured: true, help: "Get macro keys.", setup: macrosGetCmd},
+		{name: "set", args: "FILE", help: "Set macro keys from a CSV, JSON, or YAML file.", minArgs: 1, maxArgs: 1, setup: macrosSetCmd},
+		{name: "diff", args: "FILE", help: "Show what setting macro keys from a file would change.", minArgs: 1, maxArgs: 1, setup: macrosDiffCmd},
+	}},
+	{name: "brightness", structured: true, args: "[USB,BT]", help: "Get or set the usb and bluetooth LED brightness (0-255).", maxArgs: 1, setup: brightnessCmd},
+	{name: "debounce", structured: true, args: "[DURATION]", help: "Get or set the debounce duration (1ms-255ms).", maxArgs: 1, setup: debounceCmd},
+	{name: "set", help: "Set several settings at once.  If any fails the ones already set are rolled back.", setup: setCmd},
+	{name: "config", help: "Get or apply config documents.", subs: []*command{
+		{name: "get", help: "Get all settings as a config document.", setup: configGetCmd},
+		{name: "apply", args: "FILE", help: "Set only the settings in a config document, or JSON or YAML file, that differ.", minArgs: 1, maxArgs: 1, setup: configApplyCmd},
+	}},
+	{name: "backup", args: "FILE", help: "Save everything to a backup archive.", minArgs: 1, maxArgs: 1, setup: backupCmd},
+	{name: "restore", args: "FILE", help: "Set everything from a backup archive.", minArgs: 1, maxArgs: 1, setup: restoreCmd},
+	{name: "firmware", help: "Update firmware or switch between the firmware and bootloader.", subs: []*command{
+		{name: "flash", args: "FILE", help: "Flash an Intel HEX or raw binary firmware image.  This also recovers a controller stuck in the bootloader.", minArgs: 1, maxArgs: 1, setup: firmwareFlashCmd},
+		{name: "enter-boot", help: "Reboot the controller into the bootloader.", setup: firmwareEnterBootCmd},
+		{name: "exit-boot", help: "Exit the bootloader to the existing firmware.", setup: firmwareExitBootCmd},
+	}},
+	{name: "layout", help: "Physical layout tools.", subs: []*command{
+		{name: "export", args: "FILE", help: "Write the -layout physical layout as a VIA definition.  Its keymap also opens in keyboard-layout-editor.com.", minArgs: 1, maxArgs: 1, setup: layoutExportCmd},
+	}},
+	{name: "matrix", help: "Keyboard matrix tools.", subs: []*command{
+		{name: "monitor", help: "Monitor for key presses.  Press the same key twice in a row to exit.", setup: matrixMonitorCmd},
+	}},
+}
+
+func findCommand(cmds []*command, name string) *command {
+	for _, c := range cmds {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// printCommands prints the command tree with help.
+func printCommands(out io.Writer, cmds []*command) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	var walk func(prefix string, cmds []*command)
+	walk = func(prefix string, cmds []*command) {
+		for _, c := range cmds {
+			name := strings.TrimSpace(prefix + " " + c.name)
+			if len(c.subs) > 0 {
+				walk(name, c.subs)
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", strings.TrimSpace(name+" "+c.args), c.help)
+		}
+	}
+	walk("", cmds)
+	w.Flush()
+}
+
+// flagSet returns the flag set for the command at path, and for commands
+// that aren't groups the function that runs it.
+func (c *command) flagSet(path []string) (*flag.FlagSet, runFunc) {
+	name := strings.Join(path, " ")
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	var run runFunc
+	if c.setup != nil {
+		run = c.setup(fs)
+	}
+
+	fs.Usage = func() {
+		out := fs.Output()
+		if len(c.subs) > 0 {
+			fmt.Fprintf(out, "Usage: %s %s subcommand\n\n%s\n\nSubcommands:\n", os.Args[0], name, c.help)
+			printCommands(out, c.subs)
+			return
+		}
+
+		fmt.Fprintf(out, "Usage: %s", os.Args[0])
+		fmt.Fprintf(out, " %s", name)
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprint(out, " [flags]")
+		}
+		if c.args != "" {
+			fmt.Fprintf(out, " %s", c.args)
+		}
+		fmt.Fprintf(out, "\n\n%s\n", c.help)
+		if hasFlags {
+			fmt.Fprintln(out, "\nFlags:")
+			fs.PrintDefaults()
+		}
+	}
+
+	return fs, run
+}
+
+// openController opens the selected controller and applies the global
+// options.
+func openController() (blusb.Controller, error) {
+	c, err := blusb.Open(sel)
+	if err != nil {
+		if errors.Is(err, blusb.ErrControllerNotFound) {
+			if mode, err := blusb.Probe(sel); err == nil && mode == blusb.BootMode {
+				return c, fmt.Errorf("%w: it's in the bootloader, use firmware flash or firmware exit-boot", blusb.ErrControllerNotFound)
+			}
+			return c, err
+		}
+		return c, failure(exitDevice, fmt.Errorf("open device: %w", err))
+	}
+	if structured() {
+		doc.Device = c.String()
+	} else {
+		fmt.Printf("Blusb Controller - %s\n\n", c)
+	}
+
+	c.SkipSets = check
+	c.Verify = verify
+
+	return c, nil
+}
+
+// withController opens the selected controller, runs fn, and closes it.
+func withController(fn func(c blusb.Controller) error) error {
+	c, err := openController()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return fn(c)
+}
+
+func deviceError(op string, err error) error {
+	return failure(exitDevice, fmt.Errorf("%s: %w", op, err))
+}
+
+func inputError(op string, err error) error {
+	return failure(exitInput, fmt.Errorf("%s: %w", op, err))
+}
+
+// allFlag defines the -all flag for commands that set.
+func allFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("all", false, "set every attached controller")
+}
+
+// set sets the settings on the selected controller, or on every attached
+// controller with all.  More than one setting is set as a transaction so
+// a failure doesn't leave a mix of old and new.
+func set(ctx context.Context, s blusb.Settings, all bool) error {
+	if s.Brightness != nil && (s.Brightness.USB > 255 || s.Brightness.Bluetooth > 255) {
+		return failure(exitUsage, blusb.ErrInvalidBrightness)
+	}
+	if s.Debounce != 0 && (s.Debounce < time.Millisecond || s.Debounce > 255*time.Millisecond) {
+		return failure(exitUsage, blusb.ErrInvalidDebounceDur)
+	}
+
+	if all {
+		return setAll(ctx, s, false)
+	}
+
+	return withController(func(c blusb.Controller) error {
+		fmt.Println("Setting")
+		printSettings(s)
+
+		var err error
+		if setCount(s) > 1 {
+			err = c.Transaction(ctx, s, progressBar())
+		} else {
+			err = c.Set(ctx, s, progressBar())
+		}
+		if err != nil {
+			return failure(exitDevice, err)
+		}
+		fmt.Println(ok)
+
+		return nil
+	})
+}
+
+// setAll sets the settings on every attached controller concurrently and
+// prints a summary of the results.  Like set, more than one setting is set as
+// a transaction on each controller.  With apply only the settings that differ
+// are set.
+func setAll(ctx context.Context, s blusb.Settings, apply bool) error {
+	fmt.Println("Setting all controllers")
+	results, err := blusb.ForEach(ctx, func(ctx context.Context, c blusb.Controller) error {
+		c.SkipSets = check
+		c.Verify = verify
+		if apply {
+			_, err := c.Apply(ctx, s, nil)
+			return err
+		}
+		if setCount(s) > 1 {
+			return c.Transaction(ctx, s, nil)
+		}
+		return c.Set(ctx, s, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BUS\tADDRESS\tPORT\tSERIAL\tRESULT")
+	for _, r := range results {
+		res := ok
+		if r.Err != nil {
+			res = r.Err.Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", r.Device.Bus, r.Device.Address, r.Device.Port, r.Device.Serial, res)
+	}
+	w.Flush()
+	fmt.Printf("\n%d succeeded, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return failure(exitPartial, fmt.Errorf("%d of %d controllers failed", failed, len(results)))
+	}
+	return nil
+}
+
+func listCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		infos, err := blusb.List()
+		if err != nil {
+			return deviceError("list devices", err)
+		}
+		if len(infos) < 1 {
+			return blusb.ErrControllerNotFound
+		}
+
+		if structured() {
+			for _, i := range infos {
+				doc.Controllers = append(doc.Controllers, controllerReport{
+					Bus:     i.Bus,
+					Address: i.Address,
+					Port:    i.Port,
+					Serial:  i.Serial,
+					Mode:    i.Mode.String(),
+					Version: i.Version,
+				})
+			}
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "BUS\tADDRESS\tPORT\tSERIAL\tVERSION\tMODE")
+		for _, i := range infos {
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n", i.Bus, i.Address, i.Port, i.Serial, i.Version, i.Mode)
+		}
+		return w.Flush()
+	}
+}
+
+func versionCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			maj, min, err := c.GetVersion()
+			if err != nil {
+				return deviceError("get version", err)
+			}
+			if structured() {
+				doc.Version = fmt.Sprintf("%d.%d", maj, min)
+				return nil
+			}
+			fmt.Printf("Version %d.%d\n", maj, min)
+
+			return nil
+		})
+	}
+}
+
+func layersGetCmd(fs *flag.FlagSet) runFunc {
+	to := fs.String("to", "", "write to file (named keys with .keymap, .json, or .yaml, drawn with .svg or .html, otherwise CSV)")
+	qmk := fs.Bool("qmk", false, "write the -to file as a QMK keymap.json (requires an ansi, iso, or m4g-iso -layout)")
+
+	return func(ctx context.Context, args []string) error {
+		if *qmk && *to == "" {
+			return usageErrorf("layers get: -qmk requires -to")
+		}
+
+		return withController(func(c blusb.Controller) error {
+			layers, err := c.GetLayers()
+			if err != nil {
+				return deviceError("get layers", err)
+			}
+			switch {
+			case structured():
+				doc.Layers = layers
+			case lay != nil:
+				fmt.Print(renderLayers(*lay, layers))
+			default:
+				fmt.Printf("%s", layers)
+			}
+
+			if *to != "" {
+				write := writeLayers
+				if *qmk {
+					write = writeQMK
+				}
+				if err := write(layers, lay, *to); err != nil {
+					return fmt.Errorf("save layers: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+func layersSetCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+
+	return func(ctx context.Context, args []string) error {
+		layers, err := readLayers(args[0])
+		if err != nil {
+			return inputError("read layers", err)
+		}
+
+		return set(ctx, blusb.Settings{Layers: layers}, *all)
+	}
+}
+
+func layersDiffCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		layers, err := readLayers(args[0])
+		if err != nil {
+			return inputError("read layers", err)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			cur, err := c.GetLayers()
+			if err != nil {
+				return deviceError("get layers", err)
+			}
+			printDiff(blusb.DiffLayers(cur, layers), lay)
+
+			return nil
+		})
+	}
+}
+
+func macrosGetCmd(fs *flag.FlagSet) runFunc {
+	to := fs.String("to", "", "write to file (.json or .yaml, otherwise CSV)")
+
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			macros, err := c.GetMacros()
+			if err != nil {
+				return deviceError("get macros", err)
+			}
+			if structured() {
+				doc.Macros = &macros
+			} else {
+				fmt.Printf("Macro key table:\n\n%s\n", macros)
+			}
+
+			if *to != "" {
+				if err := writeMacros(macros, *to); err != nil {
+					return fmt.Errorf("save macros: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+func macrosSetCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+
+	return func(ctx context.Context, args []string) error {
+		macros, err := readMacros(args[0])
+		if err != nil {
+			return inputError("read macros", err)
+		}
+
+		return set(ctx, blusb.Settings{Macros: &macros}, *all)
+	}
+}
+
+func macrosDiffCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		macros, err := readMacros(args[0])
+		if err != nil {
+			return inputError("read macros", err)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			cur, err := c.GetMacros()
+			if err != nil {
+				return deviceError("get macros", err)
+			}
+			printDiff(blusb.DiffMacros(cur, macros), lay)
+
+			return nil
+		})
+	}
+}
+
+func brightnessCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			if structured() {
+				return usageErrorf("setting brightness doesn't support -output %s", output)
+			}
+			b := uints{Want: 2}
+			if err := b.Set(args[0]); err != nil {
+				return usageErrorf("brightness: %s", err)
+			}
+
+			return set(ctx, blusb.Settings{Brightness: &blusb.Brightness{USB: b.S[0], Bluetooth: b.S[1]}}, *all)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			usb, bt, err := c.GetBrightness()
+			if err != nil {
+				return deviceError("get brightness", err)
+			}
+			if structured() {
+				doc.Brightness = &blusb.Brightness{USB: usb, Bluetooth: bt}
+				return nil
+			}
+			fmt.Println("Brightness")
+			fmt.Printf("\tUSB is %d/255\n", usb)
+			fmt.Printf("\tBluetooth is %d/255\n", bt)
+
+			return nil
+		})
+	}
+}
+
+func debounceCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			if structured() {
+				return usageErrorf("setting debounce doesn't support -output %s", output)
+			}
+			d, err := time.ParseDuration(args[0])
+			if err != nil {
+				return usageErrorf("debounce: %s", err)
+			}
+			if d == 0 {
+				return failure(exitUsage, blusb.ErrInvalidDebounceDur)
+			}
+
+			return set(ctx, blusb.Settings{Debounce: d}, *all)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			db, err := c.GetDebounce()
+			if err != nil {
+				return deviceError("get debounce", err)
+			}
+			if structured() {
+				doc.Debounce = db.String()
+				return nil
+			}
+			fmt.Printf("Debounce time is %s\n", db)
+
+			return nil
+		})
+	}
+}
+
+func setCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+	bright := uints{Want: 2}
+	fs.Var(&bright, "brightness", "set usb,bt brightness")
+	debounce := fs.Duration("debounce", 0, "set debounce duration")
+	layersFile := fs.String("layers", "", "set layers from file")
+	macrosFile := fs.String("macros", "", "set macro keys from file")
+
+	return func(ctx context.Context, args []string) error {
+		var s blusb.Settings
+		if len(bright.S) > 0 {
+			s.Brightness = &blusb.Brightness{USB: bright.S[0], Bluetooth: bright.S[1]}
+		}
+		s.Debounce = *debounce
+		if *layersFile != "" {
+			layers, err := readLayers(*layersFile)
+			if err != nil {
+				return inputError("read layers", err)
+			}
+			s.Layers = layers
+		}
+		if *macrosFile != "" {
+			macros, err := readMacros(*macrosFile)
+			if err != nil {
+				return inputError("read macros", err)
+			}
+			s.Macros = &macros
+		}
+		if s.IsZero() {
+			return usageErrorf("set: nothing to set, use one or more flags")
+		}
+
+		return set(ctx, s, *all)
+	}
+}
+
+func configGetCmd(fs *flag.FlagSet) runFunc {
+	to := fs.String("to", "", "write to file (.json or .yaml, otherwise config document)")
+
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			cfg, err := c.GetSettings()
+			if err != nil {
+				return deviceError("get config", err)
+			}
+			text, err := cfg.MarshalConfig()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", text)
+
+			if *to != "" {
+				if err := writeConfig(cfg, *to); err != nil {
+					return fmt.Errorf("save config: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+func configApplyCmd(fs *flag.FlagSet) runFunc {
+	all := allFlag(fs)
+
+	return func(ctx context.Context, args []string) error {
+		cfg, err := readConfig(args[0])
+		if err != nil {
+			return inputError("read config", err)
+		}
+		if cfg.IsZero() {
+			return failure(exitInput, fmt.Errorf("read config: %w: nothing to set", blusb.ErrInvalidConfig))
+		}
+
+		if *all {
+			return setAll(ctx, cfg, true)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			fmt.Println("Applying config")
+			changed, err := c.Apply(ctx, cfg, progressBar())
+			printSettings(changed)
+			switch {
+			case err != nil:
+				return failure(exitDevice, err)
+			case changed.IsZero():
+				fmt.Println("Already up to date")
+			default:
+				fmt.Println(ok)
+			}
+
+			return nil
+		})
+	}
+}
+
+func backupCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			b, err := c.Backup()
+			if err != nil {
+				return deviceError("backup", err)
+			}
+			if err := writeTextFile(b, args[0]); err != nil {
+				return fmt.Errorf("save backup: %w", err)
+			}
+			fmt.Printf("Backed up version %s to %s\n", b.Version, args[0])
+
+			return nil
+		})
+	}
+}
+
+func restoreCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		b, err := readBackup(args[0])
+		if err != nil {
+			return inputError("read backup", err)
+		}
+
+		return withController(func(c blusb.Controller) error {
+			fmt.Printf("Restoring backup from %s\n", b.Created.Local().Format("2006-01-02 15:04:05"))
+			fmt.Printf("\tVersion %s\n", b.Version)
+			fmt.Printf("\t%s\n", b.Device)
+			if maj, min, err := c.GetVersion(); err == nil && fmt.Sprintf("%d.%d", maj, min) != b.Version {
+				fmt.Printf("Warning: controller is running version %d.%d\n", maj, min)
+			}
+			if err := c.Restore(ctx, b, progressBar()); err != nil {
+				return failure(exitDevice, err)
+			}
+			fmt.Println(ok)
+
+			return nil
+		})
+	}
+}
+
+func firmwareFlashCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		// The image is loaded first so a bad file is an input error and
+		// the controller is left alone.
+		img, err := firmware.Load(args[0])
+		if err != nil {
+			return inputError("load firmware", err)
+		}
+
+		mode, err := blusb.Probe(sel)
+		if err != nil {
+			return err
+		}
+
+		// A controller stuck in the bootloader is flashed directly.
+		if mode == blusb.BootMode {
+			b, err := blusb.OpenBootloader(sel)
+			if err != nil {
+				return deviceError("open bootloader", err)
+			}
+			defer b.Close()
+			fmt.Printf("Blusb Bootloader - %s\n\n", b)
+			b.SkipSets = check
+
+			fmt.Printf("Flashing firmware: %s\n", args[0])
+			if err := b.Flash(ctx, img, progressBar()); err != nil {
+				return failure(exitDevice, err)
+			}
+			fmt.Println(ok)
+
+			return nil
+		}
+
+		return withController(func(c blusb.Controller) error {
+			fmt.Printf("Flashing firmware: %s\n", args[0])
+			if err := c.Flash(ctx, img, progressBar()); err != nil {
+				return failure(exitDevice, err)
+			}
+			fmt.Println(ok)
+
+			return nil
+		})
+	}
+}
+
+func firmwareEnterBootCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			fmt.Println("Entering bootloader")
+			if err := c.EnterBoot(); err != nil {
+				return deviceError("enter bootloader", err)
+			}
+			fmt.Println(ok)
+
+			return nil
+		})
+	}
+}
+
+func firmwareExitBootCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		mode, err := blusb.Probe(sel)
+		if err != nil {
+			return err
+		}
+		if mode != blusb.BootMode {
+			fmt.Printf("Controller is running the %s, nothing to do\n", mode)
+			return nil
+		}
+
+		b, err := blusb.OpenBootloader(sel)
+		if err != nil {
+			return deviceError("open bootloader", err)
+		}
+		defer b.Close()
+		fmt.Printf("Blusb Bootloader - %s\n\n", b)
+		b.SkipSets = check
+
+		fmt.Println("Exiting bootloader")
+		if err := b.ExitBoot(); err != nil {
+			return deviceError("exit bootloader", err)
+		}
+		fmt.Println(ok)
+
+		return nil
+	}
+}
+
+func layoutExportCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, args []string) error {
+		if lay == nil {
+			return usageErrorf("layout export: requires -layout")
+		}
+
+		text, err := lay.MarshalVIA()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(args[0], text, 0644); err != nil {
+			return fmt.Errorf("save layout: %w", err)
+		}
+		fmt.Printf("Saved %s to %s\n", lay, args[0])
+
+		return nil
+	}
+}
+
+func matrixMonitorCmd(fs *flag.FlagSet) runFunc {
+	// XXX Safeguard in case something goes wrong and another keyboard
+	// isn't handy.  Maybe remove this in the future.
+	timeout := fs.Duration("timeout", 30*time.Second, "stop monitoring after duration")
+
+	return func(ctx context.Context, args []string) error {
+		return withController(func(c blusb.Controller) error {
+			// Monitoring too quickly after pressing enter to start this
+			// command bombards standard input with repeating carriage
+			// returns so sleep a little bit.
+			time.Sleep(500 * time.Millisecond)
+
+			fmt.Printf("Monitoring matrix for up to %s.  Press the same key twice in a row to exit sooner.\n\n", *timeout)
+
+			ctx, cancel := context.WithTimeout(ctx, *timeout)
+			defer cancel()
+			var prevPos blusb.MatrixPos
+			for pos := range c.MonitorMatrix(ctx) {
+				if lay != nil {
+					fmt.Printf("%s (%s)\n", pos, lay.KeyName(pos))
+				} else {
+					fmt.Println(pos)
+				}
+
+				if pos == prevPos {
+					return nil
+				}
+				prevPos = pos
+			}
+
+			return nil
+		})
+	}
+}
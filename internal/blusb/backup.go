@@ -0,0 +1,216 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backup archive format version
+const backupFormat = 1
+
+// Backup archive text format directives
+const (
+	backupFormatDir   = "format"
+	backupCreated     = "created"
+	backupVersion     = "version"
+	backupDevice      = "device"
+	backupDebounce    = configDebounce
+	backupMacros      = "macros"
+	backupChecksumDir = "sha256"
+)
+
+// Backup is a snapshot of everything the controller exposes.
+type Backup struct {
+	Created  time.Time
+	Version  string // Firmware version
+	Device   string // Device descriptor strings
+	Settings Settings
+}
+
+// Backup returns a snapshot of the controller.
+func (c Controller) Backup() (b Backup, err error) {
+	maj, min, err := c.GetVersion()
+	if err != nil {
+		return
+	}
+	b.Version = fmt.Sprintf("%d.%d", maj, min)
+	b.Device = c.String()
+	b.Created = time.Now().UTC().Truncate(time.Second)
+
+	b.Settings, err = c.GetSettings()
+	return
+}
+
+// Restore writes every setting in the backup to the controller.  A debounce
+// of 0 can't be set so it's left as is.  Progress is called after each layer
+// page is sent.
+func (c Controller) Restore(ctx context.Context, b Backup, progress ProgressFunc) error {
+	return c.Set(ctx, b.Settings, progress)
+}
+
+// MarshalText composes a backup archive.  It's a config document with
+// directives describing the snapshot ahead of it and a SHA-256 checksum of
+// everything before it on the last line:
+//
+//	format 1
+//	created TIME
+//	version MAJOR.MINOR
+//	device DESCRIPTION
+//	debounce DURATION
+//	macros HEX
+//	...
+//	sha256 CHECKSUM
+//
+// The debounce is always included, even if it's 0, and the macro table is
+// stored as hexadecimal so every byte of it is kept.
+func (b Backup) MarshalText() ([]byte, error) {
+	if b.Settings.Macros == nil {
+		return nil, fmt.Errorf("%w: missing macros", ErrInvalidBackup)
+	}
+	data, err := b.Settings.Macros.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	s := b.Settings
+	s.Debounce, s.Macros = 0, nil
+	cfg, err := s.MarshalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Blusb backup\n\n")
+	fmt.Fprintf(buf, "%s %d\n", backupFormatDir, backupFormat)
+	fmt.Fprintf(buf, "%s %s\n", backupCreated, b.Created.Format(time.RFC3339))
+	fmt.Fprintf(buf, "%s %s\n", backupVersion, b.Version)
+	fmt.Fprintf(buf, "%s %s\n", backupDevice, b.Device)
+	fmt.Fprintf(buf, "%s %s\n", backupDebounce, b.Settings.Debounce)
+	// Drop the macros ID.
+	fmt.Fprintf(buf, "%s %s\n", backupMacros, hex.EncodeToString(data[1:]))
+	// Drop the config title line.
+	buf.Write(cfg[bytes.IndexByte(cfg, '\n')+1:])
+
+	sum := sha256.Sum256(buf.Bytes())
+	fmt.Fprintf(buf, "\n%s %s\n", backupChecksumDir, hex.EncodeToString(sum[:]))
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText parses and validates a backup archive as composed by
+// MarshalText.
+func (b *Backup) UnmarshalText(text []byte) error {
+	// Verify the checksum on the last line.
+	body := bytes.TrimRight(text, "\n")
+	i := bytes.LastIndexByte(body, '\n')
+	f := strings.Fields(string(body[i+1:]))
+	if len(f) != 2 || f[0] != backupChecksumDir {
+		return fmt.Errorf("%w: missing checksum", ErrInvalidBackup)
+	}
+	sum := sha256.Sum256(bytes.TrimSuffix(body[:i+1], []byte("\n")))
+	if !strings.EqualFold(f[1], hex.EncodeToString(sum[:])) {
+		return ErrBackupChecksum
+	}
+
+	// Pull out the backup directives, blanking them so config line
+	// numbers stay correct.
+	var format int
+	var debounce bool
+	var ms *Macros
+	cfg := &bytes.Buffer{}
+	s := bufio.NewScanner(bytes.NewReader(body[:i+1]))
+	for line := 1; s.Scan(); line++ {
+		t := s.Text()
+		dir, val, _ := strings.Cut(strings.TrimSpace(t), " ")
+		val = strings.TrimSpace(val)
+
+		var err error
+		switch dir {
+		case backupFormatDir:
+			format, err = strconv.Atoi(val)
+		case backupCreated:
+			b.Created, err = time.Parse(time.RFC3339, val)
+		case backupVersion:
+			b.Version = val
+		case backupDevice:
+			b.Device = val
+		case backupDebounce:
+			b.Settings.Debounce, err = parseBackupDebounce(val)
+			debounce = true
+		case backupMacros:
+			ms, err = parseBackupMacros(val)
+		default:
+			cfg.WriteString(t)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w: %s", line, ErrInvalidBackup, err)
+		}
+		cfg.WriteByte('\n')
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if format != backupFormat {
+		return fmt.Errorf("%w: unsupported format %d", ErrInvalidBackup, format)
+	}
+
+	if err := b.Settings.UnmarshalConfig(cfg.Bytes()); err != nil {
+		return err
+	}
+	b.Settings.Macros = ms
+	switch {
+	case b.Settings.Layers == nil:
+		return fmt.Errorf("%w: missing layers", ErrInvalidBackup)
+	case b.Settings.Macros == nil:
+		return fmt.Errorf("%w: missing macros", ErrInvalidBackup)
+	case b.Settings.Brightness == nil:
+		return fmt.Errorf("%w: missing brightness", ErrInvalidBackup)
+	case !debounce:
+		return fmt.Errorf("%w: missing debounce", ErrInvalidBackup)
+	}
+
+	return nil
+}
+
+// parseBackupDebounce parses a backup debounce duration.  Unlike in a config
+// document 0 is allowed since it's whatever the controller reported.
+func parseBackupDebounce(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 || d > 255*time.Millisecond {
+		return 0, ErrInvalidDebounceDur
+	}
+
+	return d, nil
+}
+
+// parseBackupMacros parses a hexadecimal macro table.
+func parseBackupMacros(s string) (*Macros, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != numMacros*macroSize {
+		return nil, fmt.Errorf("macro table is %d bytes, want %d", len(data), numMacros*macroSize)
+	}
+
+	var ms Macros
+	if err := ms.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return &ms, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testBackup() Backup {
+	ms := Macros{
+		{Mods: LCtrl | LAlt, Key: [6]Keycode{0x4c}},
+		{Key: [6]Keycode{2: 0x04, 4: 0x05}},
+		23: {Mods: LShift, Reserved: 0x5a, Key: [6]Keycode{5: 0x04}},
+	}
+
+	return Backup{
+		Created: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+		Version: "1.5",
+		Device:  "Blusb Controller - Emulator (04b3:301c)",
+		Settings: Settings{
+			Layers:     testLayers(1),
+			Macros:     &ms,
+			Brightness: &Brightness{USB: 128, Bluetooth: 64},
+		},
+	}
+}
+
+// signBackup adds the checksum line to a backup archive body.
+func signBackup(body string) []byte {
+	return []byte(fmt.Sprintf("%s\nsha256 %x\n", body, sha256.Sum256([]byte(body))))
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, 5 * time.Millisecond} {
+		want := testBackup()
+		want.Settings.Debounce = d
+
+		text, err := want.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText: %s", err)
+		}
+		var got Backup
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("debounce %s: UnmarshalText: %s", d, err)
+		}
+
+		if !got.Created.Equal(want.Created) || got.Version != want.Version || got.Device != want.Device {
+			t.Errorf("debounce %s: header is %s %s %s", d, got.Created, got.Version, got.Device)
+		}
+		if got.Settings.Debounce != d {
+			t.Errorf("debounce is %s, want %s", got.Settings.Debounce, d)
+		}
+		if *got.Settings.Macros != *want.Settings.Macros {
+			t.Errorf("debounce %s: macros differ:\n%s", d, DiffMacros(*want.Settings.Macros, *got.Settings.Macros))
+		}
+		if *got.Settings.Brightness != *want.Settings.Brightness {
+			t.Errorf("debounce %s: brightness is %+v", d, *got.Settings.Brightness)
+		}
+		if df := DiffLayers(want.Settings.Layers, got.Settings.Layers); !df.IsZero() {
+			t.Errorf("debounce %s: layers differ:\n%s", d, df)
+		}
+	}
+}
+
+func TestBackupErrors(t *testing.T) {
+	text, err := testBackup().MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(text[:strings.LastIndex(string(text), "\nsha256")])
+	i := strings.Index(body, "macros ")
+	noMacros := body[:i] + body[i+strings.IndexByte(body[i:], '\n')+1:]
+
+	tests := []struct {
+		name string
+		text []byte
+		err  error
+	}{
+		{"no checksum", []byte(body), ErrInvalidBackup},
+		{"checksum mismatch", []byte(strings.Replace(string(text), "version 1.5", "version 1.6", 1)), ErrBackupChecksum},
+		{"unsupported format", signBackup(strings.Replace(body, "format 1", "format 2", 1)), ErrInvalidBackup},
+		{"missing debounce", signBackup(strings.Replace(body, "debounce 0s\n", "", 1)), ErrInvalidBackup},
+		{"negative debounce", signBackup(strings.Replace(body, "debounce 0s", "debounce -1ms", 1)), ErrInvalidBackup},
+		{"short macros", signBackup(strings.Replace(body, "macros ", "macros 00", 1)), ErrInvalidBackup},
+		{"missing macros", signBackup(noMacros), ErrInvalidBackup},
+	}
+
+	for _, test := range tests {
+		var b Backup
+		if err := b.UnmarshalText(test.text); !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+}
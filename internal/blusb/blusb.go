@@ -11,21 +11,8 @@ import (
 	"fmt"
 	"io"
 	"log"
-
-	"github.com/google/gousb"
-)
-
-// Bitmap request types
-const (
-	reqGetReport uint8 = 0x01
-	reqSetReport uint8 = 0x09
-)
-
-// Specific requests
-const (
-	repIn uint16 = (iota + 0x0001) << 8
-	repOut
-	repFeature
+	"strconv"
+	"strings"
 )
 
 // Bootloader features
@@ -48,74 +35,104 @@ const (
 // Defaults
 var (
 	// Controller Vendor ID
-	VID gousb.ID = 0x04b3
+	VID ID = 0x04b3
 
 	// Controller Product ID
-	PID gousb.ID = 0x301c
+	PID ID = 0x301c
+
+	// Bootloader Vendor ID
+	BootVID ID = 0x04b3
+
+	// Bootloader Product ID.  This hasn't been confirmed against a
+	// controller in bootloader mode, so change it if the bootloader
+	// enumerates as something else.
+	BootPID ID = 0x301b
+
+	// Backend used to open devices.  If nil the preferred one that's
+	// available is used.
+	DefaultBackend Backend
 
 	// Debug logger
 	Debug *log.Logger = log.New(io.Discard, "[DBUG] ", 0)
 )
 
-// Controller holds the Blusb Universal BT-USB Model M Controller context.
-type Controller struct {
-	// USB device handling context
-	ctx *gousb.Context
+// ID represents a USB vendor or product ID.
+type ID uint16
 
-	// Opened USB device
-	dev *gousb.Device
+// ParseID parses a hexadecimal USB ID, with or without a 0x prefix.
+func ParseID(s string) (ID, error) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(s), "0x"), 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
 
-	// Release claimed interface and config
-	done func()
+	return ID(v), nil
+}
+
+func (id ID) String() string {
+	return fmt.Sprintf("%04x", int(id))
+}
+
+// Set parses s into the ID so it can be used as a flag.Value.
+func (id *ID) Set(s string) (err error) {
+	*id, err = ParseID(s)
+	return
+}
+
+// device holds an opened device.
+type device struct {
+	// Feature report transport
+	t Transport
 
 	// Skip set operations so nothing is changed (enabled with check mode)
 	SkipSets bool
 }
 
-// Open opens the controller and claims the default interface.
-func Open() (Controller, error) {
-	ctx := gousb.NewContext()
+// Controller holds the Blusb Universal BT-USB Model M Controller context
+// while it's running the firmware.
+type Controller struct {
+	device
 
-	dev, err := ctx.OpenDeviceWithVIDPID(VID, PID)
-	if dev == nil {
-		return Controller{}, ErrControllerNotFound
-	}
-	if err != nil {
-		return Controller{}, err
-	}
+	// Read back and compare after every set operation
+	Verify bool
+}
 
-	// We only send control requests and don't care about the interface but
-	// Linux doesn't like it when we don't claim it.
-	_, done, err := dev.DefaultInterface()
+// Open opens the controller using the default backend.  If more than one is
+// attached the selectors choose which, otherwise the first one found is
+// opened.
+func Open(sel ...Selector) (Controller, error) {
+	t, err := openBackend(VID, PID, selector(sel))
 	if err != nil {
 		return Controller{}, err
 	}
 
-	return Controller{
-		ctx:  ctx,
-		dev:  dev,
-		done: done,
-	}, nil
+	return New(t), nil
 }
 
-// Close releases the controller.
-func (c *Controller) Close() {
-	c.done()
-	c.dev.Close()
-	c.ctx.Close()
+// New returns a controller that communicates over the transport.
+func New(t Transport) Controller {
+	return Controller{device: device{t: t}}
 }
 
-func (c Controller) String() string {
-	m, _ := c.dev.Manufacturer()
-	p, _ := c.dev.Product()
+// Mode returns FirmwareMode.
+func (c Controller) Mode() Mode { return FirmwareMode }
+
+// Close releases the device.
+func (d device) Close() {
+	d.t.Close()
+}
+
+func (d device) String() string {
+	return d.t.String()
+}
 
-	return fmt.Sprintf("Bus: %d Address: %d Manufacturer: %s (%s) Product: %s (%s)",
-		c.dev.Desc.Bus, c.dev.Desc.Address, m, c.dev.Desc.Vendor, p, c.dev.Desc.Product)
+// Info returns the device bus location and serial number.
+func (d device) Info() DeviceInfo {
+	return d.t.Info()
 }
 
-func (c Controller) getControlReport(feat byte, b []byte) (int, error) {
-	n, err := c.dev.Control(gousb.ControlInterface|gousb.ControlIn|gousb.ControlClass,
-		reqGetReport, repFeature|uint16(feat), 0, b)
+func (d device) getControlReport(feat byte, b []byte) (int, error) {
+	n, err := d.t.GetFeature(feat, b)
 	Debug.Printf("Control in (feat=%#x, err=%v):\n%s\n", feat, err, hex.Dump(b))
 	if err != nil {
 		return n, err
@@ -127,12 +144,10 @@ func (c Controller) getControlReport(feat byte, b []byte) (int, error) {
 	return n, nil
 }
 
-func (c Controller) setControlReport(b []byte) error {
-	Debug.Printf("Control out (SkipSets=%t):\n%s\n", c.SkipSets, hex.Dump(b))
-	if c.SkipSets {
+func (d device) setControlReport(b []byte) error {
+	Debug.Printf("Control out (SkipSets=%t):\n%s\n", d.SkipSets, hex.Dump(b))
+	if d.SkipSets {
 		return nil
 	}
-	_, err := c.dev.Control(gousb.ControlInterface|gousb.ControlOut|gousb.ControlClass,
-		reqSetReport, repFeature|uint16(b[0]), 0, b)
-	return err
+	return d.t.SetFeature(b)
 }
@@ -0,0 +1,32 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		s    string
+		want ID
+		err  error
+	}{
+		{"301b", 0x301b, nil},
+		{"0x301B", 0x301b, nil},
+		{"4b3", 0x04b3, nil},
+		{"", 0, ErrInvalidID},
+		{"10000", 0, ErrInvalidID},
+		{"xyz", 0, ErrInvalidID},
+	}
+
+	for _, test := range tests {
+		id, err := ParseID(test.s)
+		if !errors.Is(err, test.err) || id != test.want {
+			t.Errorf("ParseID(%q) = %s, %v, want %s, %v", test.s, id, err, test.want, test.err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+
+	"github.com/ebarkie/goblusb/internal/firmware"
+)
+
+const (
+	bootPageHeadSize = 0x3
+	bootPageDataSize = firmware.PageSize
+	bootPageSize     = bootPageHeadSize + bootPageDataSize
+)
+
+// Bootloader holds the Blusb Universal BT-USB Model M Controller context
+// while it's running the bootloader.
+type Bootloader struct {
+	device
+}
+
+// OpenBootloader opens the controller bootloader using the default backend.
+// If more than one is attached the selectors choose which, otherwise the
+// first one found is opened.
+func OpenBootloader(sel ...Selector) (Bootloader, error) {
+	t, err := openBackend(BootVID, BootPID, selector(sel))
+	if err != nil {
+		return Bootloader{}, err
+	}
+
+	return NewBootloader(t), nil
+}
+
+// NewBootloader returns a bootloader that communicates over the transport.
+func NewBootloader(t Transport) Bootloader {
+	return Bootloader{device: device{t: t}}
+}
+
+// Mode returns BootMode.
+func (b Bootloader) Mode() Mode { return BootMode }
+
+// ExitBoot signals the bootloader to exit and boot the firmware.
+func (b Bootloader) ExitBoot() error {
+	data := make([]byte, 8)
+	data[0] = bootExit
+	return b.setControlReport(data)
+}
+
+// WritePage sends one page of firmware to be flashed at addr.
+func (b Bootloader) WritePage(addr int, data []byte) error {
+	// Boot page
+	//
+	//		          1                   2                   3
+	//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	//   |       ID      |         Page Address          | Data          |
+	//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	if len(data) > bootPageDataSize {
+		return ErrInvalidPage
+	}
+
+	page := make([]byte, bootPageSize)
+	page[0] = bootPageData
+	page[1], page[2] = byte(addr), byte(addr>>8)
+	copy(page[bootPageHeadSize:], data)
+
+	return b.setControlReport(page)
+}
+
+// UpdateFirmwareContext flashes the Intel HEX or raw binary firmware image
+// in filename, calling progress after each page, and then boots the new
+// firmware.  This is used to recover a controller that was left in the
+// bootloader by an interrupted update.
+func (b Bootloader) UpdateFirmwareContext(ctx context.Context, filename string, progress ProgressFunc) error {
+	img, err := firmware.Load(filename)
+	if err != nil {
+		return err
+	}
+
+	return b.Flash(ctx, img, progress)
+}
+
+// Flash writes the image one page at a time, calling progress after each
+// page, and then exits the bootloader to boot the new firmware.  If the
+// context is canceled it stops between pages and returns the context error
+// without exiting the bootloader.
+func (b Bootloader) Flash(ctx context.Context, img firmware.Image, progress ProgressFunc) error {
+	for i := 0; i < img.Pages(); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		addr, data := img.Page(i)
+		Debug.Printf("Writing firmware page %d/%d at %#04x", i+1, img.Pages(), addr)
+		if err := b.WritePage(addr, data); err != nil {
+			return err
+		}
+		progress.report(Progress{
+			Page:       i + 1,
+			Pages:      img.Pages(),
+			Bytes:      addr + len(data),
+			TotalBytes: len(img),
+		})
+	}
+
+	return b.ExitBoot()
+}
@@ -26,5 +26,12 @@ func (c Controller) SetBrightness(usb, bt uint) error {
 
 	data := make([]byte, 8)
 	copy(data, []byte{firmBrightness, byte(usb), byte(bt)})
-	return c.setControlReport(data)
+	if err := c.setControlReport(data); err != nil {
+		return err
+	}
+
+	if c.verifying() {
+		return c.verifyBrightness(usb, bt)
+	}
+	return nil
 }
@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config text format directives
+const (
+	configBrightness = "brightness"
+	configDebounce   = "debounce"
+	configMacro      = "macro"
+)
+
+// MarshalConfig composes a config document of the settings.  It's the
+// keymap format with directives for the other settings ahead of the layers:
+//
+//	brightness USB BT
+//	debounce DURATION
+//	macro N MACRO
+//
+// Only non-empty macros are included.  Settings that are zero are left out.
+func (s Settings) MarshalConfig() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Blusb config\n")
+
+	if s.Brightness != nil || s.Debounce > 0 || s.Macros != nil {
+		buf.WriteByte('\n')
+	}
+	if s.Brightness != nil {
+		fmt.Fprintf(buf, "%s %d %d\n", configBrightness, s.Brightness.USB, s.Brightness.Bluetooth)
+	}
+	if s.Debounce > 0 {
+		fmt.Fprintf(buf, "%s %s\n", configDebounce, s.Debounce)
+	}
+	if s.Macros != nil {
+		n := 0
+		for i, m := range s.Macros {
+			if m != (Macro{}) {
+				fmt.Fprintf(buf, "%s %d %s\n", configMacro, i+1, m)
+				n++
+			}
+		}
+		if n == 0 {
+			// An empty table still needs a directive to be set.
+			fmt.Fprintf(buf, "%s 1 %s\n", configMacro, Macro{})
+		}
+	}
+
+	if s.Layers != nil {
+		text, err := s.Layers.MarshalKeymap()
+		if err != nil {
+			return nil, err
+		}
+		// Drop the keymap title line.
+		buf.Write(text[bytes.IndexByte(text, '\n')+1:])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalConfig parses a config document as composed by MarshalConfig.
+// Settings without a directive are left zero so they aren't changed.  If
+// there are any macro directives then the whole macro table is set and
+// slots without one are empty.
+func (s *Settings) UnmarshalConfig(text []byte) error {
+	// Directive lines are blanked out of the keymap so its line numbers stay
+	// correct.
+	keymap := &bytes.Buffer{}
+	var hasLayers bool
+
+	sc := bufio.NewScanner(bytes.NewReader(text))
+	for line := 1; sc.Scan(); line++ {
+		t := sc.Text()
+		if i := strings.Index(t, keymapComment); i >= 0 {
+			t = t[:i]
+		}
+		f := strings.Fields(t)
+		if len(f) < 1 {
+			keymap.WriteByte('\n')
+			continue
+		}
+
+		var err error
+		switch strings.ToLower(f[0]) {
+		case configBrightness:
+			err = s.parseBrightness(f[1:])
+		case configDebounce:
+			err = s.parseDebounce(f[1:])
+		case configMacro:
+			err = s.parseMacro(f[1:])
+		default:
+			hasLayers = true
+			keymap.WriteString(t)
+			keymap.WriteByte('\n')
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		keymap.WriteByte('\n')
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	if hasLayers {
+		var ls Layers
+		if err := ls.UnmarshalKeymap(keymap.Bytes()); err != nil {
+			return err
+		}
+		s.Layers = ls
+	}
+
+	return nil
+}
+
+func (s *Settings) parseBrightness(f []string) error {
+	if len(f) != 2 {
+		return fmt.Errorf("%w: %s needs usb and bluetooth values", ErrInvalidConfig, configBrightness)
+	}
+
+	var b Brightness
+	for i, v := range []*uint{&b.USB, &b.Bluetooth} {
+		u, err := strconv.ParseUint(f[i], 10, 8)
+		if err != nil {
+			return ErrInvalidBrightness
+		}
+		*v = uint(u)
+	}
+	s.Brightness = &b
+
+	return nil
+}
+
+func (s *Settings) parseDebounce(f []string) error {
+	if len(f) != 1 {
+		return fmt.Errorf("%w: %s needs a duration", ErrInvalidConfig, configDebounce)
+	}
+
+	d, err := time.ParseDuration(f[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, err)
+	}
+	if d < 1*time.Millisecond || d > 255*time.Millisecond {
+		return ErrInvalidDebounceDur
+	}
+	s.Debounce = d
+
+	return nil
+}
+
+func (s *Settings) parseMacro(f []string) error {
+	if len(f) < 2 {
+		return fmt.Errorf("%w: %s needs a number and keys", ErrInvalidConfig, configMacro)
+	}
+
+	n, err := strconv.Atoi(f[0])
+	if err != nil || n < 1 || n > numMacros {
+		return fmt.Errorf("%w: %s number must be between 1 and %d", ErrInvalidConfig, configMacro, numMacros)
+	}
+	m, err := ParseMacro(strings.Join(f[1:], ""))
+	if err != nil {
+		return err
+	}
+
+	if s.Macros == nil {
+		s.Macros = &Macros{}
+	}
+	s.Macros[n-1] = m
+
+	return nil
+}
+
+// GetSettings returns all of the settings stored in the controller.
+func (c Controller) GetSettings() (s Settings, err error) {
+	s.Layers, err = c.GetLayers()
+	if err != nil {
+		return
+	}
+
+	var ms Macros
+	ms, err = c.GetMacros()
+	if err != nil {
+		return
+	}
+	s.Macros = &ms
+
+	var b Brightness
+	b.USB, b.Bluetooth, err = c.GetBrightness()
+	if err != nil {
+		return
+	}
+	s.Brightness = &b
+
+	s.Debounce, err = c.GetDebounce()
+	return
+}
+
+// Apply makes the controller match the non-zero settings.  The current
+// settings are read first and only the ones that differ are set, which
+// avoids needless EEPROM writes.  The settings that were set are returned.
+func (c Controller) Apply(ctx context.Context, s Settings, progress ProgressFunc) (changed Settings, err error) {
+	if s.Brightness != nil {
+		var b Brightness
+		b.USB, b.Bluetooth, err = c.GetBrightness()
+		if err != nil {
+			return
+		}
+		if b != *s.Brightness {
+			changed.Brightness = s.Brightness
+		}
+	}
+
+	if s.Debounce > 0 {
+		var d time.Duration
+		d, err = c.GetDebounce()
+		if err != nil {
+			return
+		}
+		if d != s.Debounce {
+			changed.Debounce = s.Debounce
+		}
+	}
+
+	if s.Macros != nil {
+		var ms Macros
+		ms, err = c.GetMacros()
+		if err != nil {
+			return
+		}
+		if !DiffMacros(ms, *s.Macros).IsZero() {
+			changed.Macros = s.Macros
+		}
+	}
+
+	if s.Layers != nil {
+		var ls Layers
+		ls, err = c.GetLayers()
+		if err != nil {
+			return
+		}
+		if !DiffLayers(ls, s.Layers).IsZero() {
+			changed.Layers = s.Layers
+		}
+	}
+
+	err = c.Set(ctx, changed, progress)
+	return
+}
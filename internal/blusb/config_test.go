@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Applying the config of a controller back to it doesn't change anything.
+func TestConfigApplyRoundTrip(t *testing.T) {
+	c := New(NewEmulator())
+	ms := Macros{
+		{Mods: LCtrl | LAlt, Key: [6]Keycode{0x4c}},
+		{Key: [6]Keycode{2: 0x04, 4: 0x05}},
+		{Mods: LShift, Reserved: 0x5a, Key: [6]Keycode{0x04}},
+		23: {Reserved: 1},
+	}
+	if err := c.Set(context.Background(), Settings{
+		Layers:     testLayers(1),
+		Macros:     &ms,
+		Brightness: &Brightness{USB: 128, Bluetooth: 64},
+		Debounce:   5 * time.Millisecond,
+	}, nil); err != nil {
+		t.Fatalf("Set: %s", err)
+	}
+
+	s, err := c.GetSettings()
+	if err != nil {
+		t.Fatalf("GetSettings: %s", err)
+	}
+	text, err := s.MarshalConfig()
+	if err != nil {
+		t.Fatalf("MarshalConfig: %s", err)
+	}
+	var got Settings
+	if err := got.UnmarshalConfig(text); err != nil {
+		t.Fatalf("UnmarshalConfig: %s", err)
+	}
+	if *got.Macros != ms {
+		t.Errorf("macros differ:\n%s", DiffMacros(ms, *got.Macros))
+	}
+
+	changed, err := c.Apply(context.Background(), got, nil)
+	if err != nil {
+		t.Fatalf("Apply: %s", err)
+	}
+	if changed.Layers != nil || changed.Macros != nil || changed.Brightness != nil || changed.Debounce != 0 {
+		t.Errorf("Apply changed %+v", changed)
+	}
+}
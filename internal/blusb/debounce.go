@@ -25,5 +25,12 @@ func (c Controller) SetDebounce(dur time.Duration) error {
 
 	data := make([]byte, 8)
 	copy(data, []byte{firmDebounce, byte(dur.Milliseconds())})
-	return c.setControlReport(data)
+	if err := c.setControlReport(data); err != nil {
+		return err
+	}
+
+	if c.verifying() {
+		return c.verifyDebounce(dur)
+	}
+	return nil
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// KeyChange is a matrix position whose keycode differs between two layers.
+type KeyChange struct {
+	Layer    int // Layer number starting at 1
+	Pos      MatrixPos
+	From, To Keycode
+}
+
+// MacroChange is a macro slot that differs between two macro tables.
+type MacroChange struct {
+	Slot     int // Macro number starting at 1
+	From, To Macro
+}
+
+// Diff represents the changes needed to go from one set of layers or macros
+// to another.
+type Diff struct {
+	Keys          []KeyChange
+	AddedLayers   []int
+	RemovedLayers []int
+	Macros        []MacroChange
+}
+
+// DiffLayers returns the changes from one set of layers to another.  Keys
+// are only compared on layers that exist in both.
+func DiffLayers(from, to Layers) (d Diff) {
+	for i := range to {
+		if i >= len(from) {
+			d.AddedLayers = append(d.AddedLayers, i+1)
+			continue
+		}
+		for row := range to[i].Matrix {
+			for col := range to[i].Matrix[row] {
+				a, b := from[i].Matrix[row][col], to[i].Matrix[row][col]
+				if a != b {
+					d.Keys = append(d.Keys, KeyChange{
+						Layer: i + 1,
+						Pos:   MatrixPos{Row: row, Col: col},
+						From:  a,
+						To:    b,
+					})
+				}
+			}
+		}
+	}
+	for i := len(to); i < len(from); i++ {
+		d.RemovedLayers = append(d.RemovedLayers, i+1)
+	}
+
+	return
+}
+
+// DiffMacros returns the changes from one macro table to another.
+func DiffMacros(from, to Macros) (d Diff) {
+	for i := range to {
+		if from[i] != to[i] {
+			d.Macros = append(d.Macros, MacroChange{Slot: i + 1, From: from[i], To: to[i]})
+		}
+	}
+
+	return
+}
+
+// IsZero indicates if there are no changes.
+func (d Diff) IsZero() bool {
+	return len(d.Keys) == 0 && len(d.AddedLayers) == 0 &&
+		len(d.RemovedLayers) == 0 && len(d.Macros) == 0
+}
+
+// Format describes each change on its own line.  If name isn't nil it's
+// used to label matrix positions with physical key names, e.g.
+// "Layer 2 R3 C12 (Caps Lock): LCTRL -> ESC".  Positions it returns an empty
+// name for are left unlabeled.
+func (d Diff) Format(name func(MatrixPos) string) string {
+	buf := &bytes.Buffer{}
+	for _, l := range d.AddedLayers {
+		fmt.Fprintf(buf, "Layer %d added\n", l)
+	}
+	for _, l := range d.RemovedLayers {
+		fmt.Fprintf(buf, "Layer %d removed\n", l)
+	}
+	for _, k := range d.Keys {
+		fmt.Fprintf(buf, "Layer %d R%d C%d", k.Layer, k.Pos.Row, k.Pos.Col)
+		if name != nil {
+			if n := name(k.Pos); n != "" {
+				fmt.Fprintf(buf, " (%s)", n)
+			}
+		}
+		fmt.Fprintf(buf, ": %s -> %s\n", k.From, k.To)
+	}
+	for _, m := range d.Macros {
+		fmt.Fprintf(buf, "Macro M%02d: %s -> %s\n", m.Slot, m.From, m.To)
+	}
+
+	return buf.String()
+}
+
+func (d Diff) String() string {
+	return d.Format(nil)
+}
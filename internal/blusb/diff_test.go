@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import "testing"
+
+func TestDiffLayers(t *testing.T) {
+	from := testLayers(3)
+	to := append(append(Layers{}, from...), Layer{})
+	to[2].Matrix[3][12] = ModifierKey(LCtrl)
+	to[2].Matrix[0][1] = 0x29
+
+	d := DiffLayers(from, to)
+	if d.IsZero() {
+		t.Fatal("IsZero is true")
+	}
+	want := "Layer 4 added\n" +
+		"Layer 3 R0 C1: 0x201 -> ESC\n" +
+		"Layer 3 R3 C12: 0x248 -> LCTRL\n"
+	if s := d.String(); s != want {
+		t.Errorf("String() =\n%s\nwant\n%s", s, want)
+	}
+
+	d = DiffLayers(to, from)
+	want = "Layer 4 removed\n" +
+		"Layer 3 R0 C1: ESC -> 0x201\n" +
+		"Layer 3 R3 C12: LCTRL -> 0x248\n"
+	if s := d.String(); s != want {
+		t.Errorf("reversed String() =\n%s\nwant\n%s", s, want)
+	}
+
+	if d := DiffLayers(from, from); !d.IsZero() || d.String() != "" {
+		t.Errorf("same layers: %+v", d)
+	}
+}
+
+func TestDiffFormat(t *testing.T) {
+	from := Layers{Layer{}}
+	to := Layers{Layer{}}
+	to[0].Matrix[3][12] = 0x29
+	to[0].Matrix[7][0] = 0x04
+	d := DiffLayers(from, to)
+
+	var ms Macros
+	ms[1] = Macro{Mods: LCtrl, Key: [6]Keycode{0x04}}
+	d.Macros = DiffMacros(Macros{}, ms).Macros
+
+	names := map[MatrixPos]string{{Row: 3, Col: 12}: "Caps Lock"}
+	want := "Layer 1 R3 C12 (Caps Lock): NONE -> ESC\n" +
+		"Layer 1 R7 C0: NONE -> A\n" +
+		"Macro M02: NONE -> LCTRL+A\n"
+	if s := d.Format(func(p MatrixPos) string { return names[p] }); s != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", s, want)
+	}
+
+	want = "Layer 1 R3 C12: NONE -> ESC\n" +
+		"Layer 1 R7 C0: NONE -> A\n" +
+		"Macro M02: NONE -> LCTRL+A\n"
+	if s := d.String(); s != want {
+		t.Errorf("String() =\n%s\nwant\n%s", s, want)
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ebarkie/goblusb/internal/firmware"
+)
+
+// errStall is returned by the emulator for requests the current mode doesn't
+// support, which is what a real device does by stalling the control pipe.
+var errStall = errors.New("emulator: pipe stalled")
+
+// Emulator is an in-memory software emulation of the controller's feature
+// report protocol, in both firmware and bootloader mode.  It implements
+// Transport so it can be passed to New, and Backend so it can be used as the
+// DefaultBackend.
+type Emulator struct {
+	mu sync.Mutex
+
+	mode Mode
+	info DeviceInfo
+
+	major, minor byte
+	usb, bt      byte
+	debounce     byte
+	layers       Layers
+	macros       Macros
+	presses      []MatrixPos
+
+	// Layer paging state
+	layersIn    *bytes.Buffer
+	layersOut   []byte
+	layersPage  int
+	layersPages int
+
+	// Flash contents written by the bootloader
+	flash []byte
+}
+
+// NewEmulator returns an emulator running the firmware with one empty layer
+// and an empty macro table.
+func NewEmulator() *Emulator {
+	return &Emulator{
+		major:    1,
+		minor:    0,
+		usb:      255,
+		bt:       255,
+		debounce: 5,
+		layers:   Layers{Layer{}},
+		flash:    bytes.Repeat([]byte{0xff}, firmware.BootStart),
+		info: DeviceInfo{
+			Bus:     1,
+			Address: 1,
+			Port:    "1-1",
+			Serial:  "EMULATOR",
+		},
+	}
+}
+
+// match indicates if the emulator is currently enumerated as vid and pid.
+func (e *Emulator) match(vid, pid ID) bool {
+	switch e.mode {
+	case FirmwareMode:
+		return vid == VID && pid == PID
+	case BootMode:
+		return vid == BootVID && pid == BootPID
+	}
+
+	return false
+}
+
+// setMode switches modes, which re-enumerates with a new address like the
+// real controller.
+func (e *Emulator) setMode(m Mode) {
+	e.mode = m
+	e.info.Address++
+}
+
+// List returns the emulator if it's running in the mode that vid and pid
+// identify.
+func (e *Emulator) List(vid, pid ID) ([]DeviceInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.match(vid, pid) {
+		return nil, nil
+	}
+
+	return []DeviceInfo{e.info}, nil
+}
+
+// Open returns the emulator if it's running in the mode that vid and pid
+// identify and it's selected.
+func (e *Emulator) Open(vid, pid ID, sel Selector) (Transport, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.match(vid, pid) || !sel.Match(e.info) {
+		return nil, ErrControllerNotFound
+	}
+
+	return e, nil
+}
+
+// Mode returns the mode the emulator is running in.
+func (e *Emulator) Mode() Mode {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.mode
+}
+
+// Press queues a key press to be returned by the next matrix read.
+func (e *Emulator) Press(pos MatrixPos) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.presses = append(e.presses, pos)
+}
+
+// Flash returns a copy of the application flash contents.
+func (e *Emulator) Flash() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]byte{}, e.flash...)
+}
+
+// GetFeature reads feature report id into b.
+func (e *Emulator) GetFeature(id byte, b []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.mode != FirmwareMode {
+		return 0, errStall
+	}
+
+	for i := range b {
+		b[i] = 0
+	}
+	switch id {
+	case firmLayers:
+		return e.readLayersPage(b)
+	case firmMacros:
+		data, _ := e.macros.MarshalBinary()
+		return copy(b, data[1:]), nil
+	case firmMatrix:
+		if len(e.presses) > 0 {
+			b[0], b[1] = byte(e.presses[0].Row), byte(e.presses[0].Col)
+			e.presses = e.presses[1:]
+		}
+	case firmBrightness:
+		b[0], b[1] = e.usb, e.bt
+	case firmGetVersion:
+		b[0], b[1] = e.major, e.minor
+	case firmDebounce:
+		b[0] = e.debounce
+	default:
+		return 0, errStall
+	}
+
+	return len(b), nil
+}
+
+// readLayersPage reads the next layers page.  Like the real firmware the ID
+// in the page header comes through as zero.
+func (e *Emulator) readLayersPage(b []byte) (int, error) {
+	if len(b) < layersPageHeadSize {
+		return 0, errStall
+	}
+	size := len(b) - layersPageHeadSize
+
+	if e.layersOut == nil {
+		e.layersOut, _ = e.layers.MarshalBinary()
+		e.layersPages = (len(e.layersOut) + size - 1) / size
+		e.layersPage = 0
+	}
+
+	e.layersPage++
+	b[0], b[1], b[2] = 0, byte(e.layersPages), byte(e.layersPage)
+	n := copy(b[layersPageHeadSize:], e.layersOut)
+	e.layersOut = e.layersOut[n:]
+	copy(b[layersPageHeadSize+n:], bytes.Repeat([]byte{0xff}, size-n))
+
+	if e.layersPage >= e.layersPages {
+		e.layersOut = nil
+	}
+
+	return len(b), nil
+}
+
+// SetFeature handles the feature report in b.
+func (e *Emulator) SetFeature(b []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(b) < 1 {
+		return errStall
+	}
+
+	if e.mode == BootMode {
+		return e.setBootFeature(b)
+	}
+
+	switch b[0] {
+	case firmLayers:
+		return e.writeLayersPage(b)
+	case firmMacros:
+		if len(b) < 1+numMacros*macroSize {
+			return errStall
+		}
+		return e.macros.UnmarshalBinary(b[1:])
+	case firmBrightness:
+		if len(b) < 3 {
+			return errStall
+		}
+		e.usb, e.bt = b[1], b[2]
+	case firmDebounce:
+		if len(b) < 2 {
+			return errStall
+		}
+		e.debounce = b[1]
+	case firmEnterBoot:
+		e.setMode(BootMode)
+	default:
+		return errStall
+	}
+
+	return nil
+}
+
+// writeLayersPage accepts the next layers page and updates the layers once
+// the last one is received.
+func (e *Emulator) writeLayersPage(b []byte) error {
+	if len(b) < layersPageHeadSize {
+		return errStall
+	}
+	total, current := int(b[1]), int(b[2])
+
+	if current == 1 {
+		e.layersIn = &bytes.Buffer{}
+	}
+	if e.layersIn == nil {
+		return errStall
+	}
+	e.layersIn.Write(b[layersPageHeadSize:])
+
+	if current == total {
+		var ls Layers
+		if err := ls.UnmarshalBinary(e.layersIn.Bytes()); err != nil {
+			return err
+		}
+		e.layers = ls
+		e.layersIn = nil
+	}
+
+	return nil
+}
+
+func (e *Emulator) setBootFeature(b []byte) error {
+	switch b[0] {
+	case bootPageData:
+		if len(b) < bootPageHeadSize {
+			return errStall
+		}
+		addr := int(b[1]) | int(b[2])<<8
+		data := b[bootPageHeadSize:]
+		if addr+len(data) > len(e.flash) {
+			return errStall
+		}
+		copy(e.flash[addr:], data)
+	case bootExit:
+		e.setMode(FirmwareMode)
+	default:
+		return errStall
+	}
+
+	return nil
+}
+
+// Info returns the emulated bus location and serial number.
+func (e *Emulator) Info() DeviceInfo {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.info
+}
+
+// Close does nothing since the emulator state outlives any one handle.
+func (e *Emulator) Close() error { return nil }
+
+func (e *Emulator) String() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	id := fmt.Sprintf("%s:%s", VID, PID)
+	if e.mode == BootMode {
+		id = fmt.Sprintf("%s:%s", BootVID, BootPID)
+	}
+
+	return fmt.Sprintf("Emulator (%s) Mode: %s", id, e.mode)
+}
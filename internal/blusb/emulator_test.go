@@ -0,0 +1,468 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebarkie/goblusb/internal/firmware"
+)
+
+// testLayers returns n layers with every key set to a value unique to its
+// layer and position.
+func testLayers(n int) Layers {
+	ls := make(Layers, n)
+	for i := range ls {
+		for r := range ls[i].Matrix {
+			for c := range ls[i].Matrix[r] {
+				ls[i].Matrix[r][c] = Keycode(i<<8 | r*MatrixCols + c)
+			}
+		}
+	}
+
+	return ls
+}
+
+func TestEmulatorLayers(t *testing.T) {
+	tests := []struct {
+		name   string
+		layers Layers
+		pages  int
+	}{
+		{"one empty", Layers{Layer{}}, 2},
+		{"one", testLayers(1), 2},
+		{"two", testLayers(2), 3},
+		{"four", testLayers(4), 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := New(NewEmulator())
+
+			var got []Progress
+			err := c.SetLayersContext(context.Background(), test.layers, func(p Progress) {
+				got = append(got, p)
+			})
+			if err != nil {
+				t.Fatalf("SetLayersContext: %s", err)
+			}
+			if len(got) != test.pages {
+				t.Errorf("progress called %d times, want %d", len(got), test.pages)
+			}
+			if len(got) > 0 && !got[len(got)-1].Done() {
+				t.Errorf("last progress %+v isn't done", got[len(got)-1])
+			}
+
+			ls, err := c.GetLayers()
+			if err != nil {
+				t.Fatalf("GetLayers: %s", err)
+			}
+			if d := DiffLayers(test.layers, ls); !d.IsZero() {
+				t.Errorf("layers read back differ:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestEmulatorLayersPageHeader(t *testing.T) {
+	e := NewEmulator()
+	e.layers = testLayers(2)
+
+	// Like the real firmware the page ID comes through as zero.
+	for page := 1; page <= 3; page++ {
+		b := make([]byte, layersPageSize)
+		if _, err := e.GetFeature(firmLayers, b); err != nil {
+			t.Fatalf("page %d: %s", page, err)
+		}
+		if b[0] != 0 || b[1] != 3 || int(b[2]) != page {
+			t.Errorf("page %d header is % x, want 00 03 %02x", page, b[:3], page)
+		}
+	}
+
+	ls, err := New(e).GetLayers()
+	if err != nil {
+		t.Fatalf("GetLayers: %s", err)
+	}
+	if d := DiffLayers(e.layers, ls); !d.IsZero() {
+		t.Errorf("layers read back differ:\n%s", d)
+	}
+}
+
+func TestEmulatorMacros(t *testing.T) {
+	var full Macros
+	for i := range full {
+		full[i] = Macro{Mods: Modifier(i), Reserved: byte(i), Key: [6]Keycode{4, 5, 6, 7, 8, Keycode(9 + i)}}
+	}
+
+	tests := []struct {
+		name   string
+		macros Macros
+	}{
+		{"empty", Macros{}},
+		{"first", Macros{{Mods: LCtrl | LAlt, Key: [6]Keycode{0x4c}}}},
+		{"sixth key", Macros{23: {Key: [6]Keycode{5: 0x04}}}},
+		{"full", full},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := New(NewEmulator())
+			if err := c.SetMacros(test.macros); err != nil {
+				t.Fatalf("SetMacros: %s", err)
+			}
+
+			ms, err := c.GetMacros()
+			if err != nil {
+				t.Fatalf("GetMacros: %s", err)
+			}
+			if ms != test.macros {
+				t.Errorf("macros read back differ:\n%s", DiffMacros(test.macros, ms))
+			}
+		})
+	}
+}
+
+func TestEmulatorBrightness(t *testing.T) {
+	tests := []struct {
+		usb, bt uint
+		err     error
+	}{
+		{0, 0, nil},
+		{128, 64, nil},
+		{255, 255, nil},
+		{256, 0, ErrInvalidBrightness},
+		{0, 256, ErrInvalidBrightness},
+	}
+
+	for _, test := range tests {
+		c := New(NewEmulator())
+		err := c.SetBrightness(test.usb, test.bt)
+		if !errors.Is(err, test.err) {
+			t.Errorf("SetBrightness(%d, %d) error %v, want %v", test.usb, test.bt, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		usb, bt, err := c.GetBrightness()
+		if err != nil {
+			t.Fatalf("GetBrightness: %s", err)
+		}
+		if usb != test.usb || bt != test.bt {
+			t.Errorf("GetBrightness() = %d, %d, want %d, %d", usb, bt, test.usb, test.bt)
+		}
+	}
+}
+
+func TestEmulatorDebounce(t *testing.T) {
+	tests := []struct {
+		dur time.Duration
+		err error
+	}{
+		{1 * time.Millisecond, nil},
+		{5 * time.Millisecond, nil},
+		{255 * time.Millisecond, nil},
+		{0, ErrInvalidDebounceDur},
+		{256 * time.Millisecond, ErrInvalidDebounceDur},
+	}
+
+	for _, test := range tests {
+		c := New(NewEmulator())
+		err := c.SetDebounce(test.dur)
+		if !errors.Is(err, test.err) {
+			t.Errorf("SetDebounce(%s) error %v, want %v", test.dur, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		dur, err := c.GetDebounce()
+		if err != nil {
+			t.Fatalf("GetDebounce: %s", err)
+		}
+		if dur != test.dur {
+			t.Errorf("GetDebounce() = %s, want %s", dur, test.dur)
+		}
+	}
+}
+
+func TestEmulatorShortReports(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"empty", []byte{}},
+		{"brightness", []byte{firmBrightness, 1}},
+		{"debounce", []byte{firmDebounce}},
+		{"macros", []byte{firmMacros, 0, 0}},
+		{"layers", []byte{firmLayers, 1}},
+	}
+
+	for _, test := range tests {
+		if err := NewEmulator().SetFeature(test.b); !errors.Is(err, errStall) {
+			t.Errorf("%s: SetFeature error %v, want %v", test.name, err, errStall)
+		}
+	}
+}
+
+func TestEmulatorFlash(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"one page", firmware.PageSize},
+		{"partial page", firmware.PageSize + 1},
+		{"application area", firmware.BootStart},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := make([]byte, test.size)
+			for i := range data {
+				data[i] = byte(i * 7)
+			}
+			img, err := firmware.ParseBin(data)
+			if err != nil {
+				t.Fatalf("ParseBin: %s", err)
+			}
+
+			e := NewEmulator()
+			if err := New(e).EnterBoot(); err != nil {
+				t.Fatalf("EnterBoot: %s", err)
+			}
+			if e.Mode() != BootMode {
+				t.Fatalf("mode after EnterBoot is %s", e.Mode())
+			}
+
+			var got []Progress
+			err = NewBootloader(e).Flash(context.Background(), img, func(p Progress) {
+				got = append(got, p)
+			})
+			if err != nil {
+				t.Fatalf("Flash: %s", err)
+			}
+			if len(got) != img.Pages() {
+				t.Errorf("progress called %d times, want %d", len(got), img.Pages())
+			}
+			for i, p := range got {
+				if p.Page != i+1 || p.Pages != img.Pages() || p.TotalBytes != len(img) {
+					t.Errorf("progress %d is %+v", i, p)
+				}
+			}
+			if e.Mode() != FirmwareMode {
+				t.Errorf("mode after Flash is %s", e.Mode())
+			}
+			if f := e.Flash(); !bytes.Equal(f[:len(img)], img) {
+				t.Error("flash contents don't match the image")
+			}
+		})
+	}
+}
+
+func TestEmulatorFlashCanceled(t *testing.T) {
+	e := NewEmulator()
+	e.setMode(BootMode)
+	img, _ := firmware.ParseBin(make([]byte, 4*firmware.PageSize))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var pages int
+	err := NewBootloader(e).Flash(ctx, img, func(p Progress) {
+		if pages++; pages == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Flash error %v, want %v", err, context.Canceled)
+	}
+	if pages != 2 {
+		t.Errorf("%d pages written, want 2", pages)
+	}
+	if e.Mode() != BootMode {
+		t.Errorf("mode after cancel is %s, want %s", e.Mode(), BootMode)
+	}
+}
+
+// faultyTransport is an emulator that mishandles set feature reports with
+// one ID.  They're ignored, like a controller that doesn't store the
+// setting, or fail with err if it isn't nil.
+type faultyTransport struct {
+	*Emulator
+	id  byte
+	err error
+}
+
+func (t faultyTransport) SetFeature(b []byte) error {
+	if len(b) > 0 && b[0] == t.id {
+		return t.err
+	}
+
+	return t.Emulator.SetFeature(b)
+}
+
+// useEmulator makes a new emulator the default backend for the test.
+func useEmulator(t *testing.T) *Emulator {
+	e := NewEmulator()
+	prevBackend, prevPoll := DefaultBackend, waitPoll
+	DefaultBackend, waitPoll = e, time.Millisecond
+	t.Cleanup(func() { DefaultBackend, waitPoll = prevBackend, prevPoll })
+
+	return e
+}
+
+func TestEmulatorProbe(t *testing.T) {
+	e := useEmulator(t)
+
+	tests := []struct {
+		sel  Selector
+		mode Mode
+		err  error
+	}{
+		{Selector{}, FirmwareMode, nil},
+		{Selector{Serial: "EMULATOR"}, FirmwareMode, nil},
+		{Selector{Serial: "OTHER"}, 0, ErrControllerNotFound},
+	}
+
+	for _, test := range tests {
+		m, err := Probe(test.sel)
+		if !errors.Is(err, test.err) || m != test.mode {
+			t.Errorf("Probe(%+v) = %s, %v, want %s, %v", test.sel, m, err, test.mode, test.err)
+		}
+	}
+
+	e.setMode(BootMode)
+	if m, err := Probe(); m != BootMode || err != nil {
+		t.Errorf("Probe() in bootloader = %s, %v", m, err)
+	}
+}
+
+func TestEmulatorWait(t *testing.T) {
+	e := useEmulator(t)
+	sel := Selector{Serial: "EMULATOR"}
+
+	tests := []struct {
+		name  string
+		mode  Mode
+		modes []Mode
+		want  Mode
+		err   error
+	}{
+		{"firmware", FirmwareMode, []Mode{FirmwareMode}, FirmwareMode, nil},
+		{"bootloader", BootMode, []Mode{BootMode}, BootMode, nil},
+		{"any firmware", FirmwareMode, nil, FirmwareMode, nil},
+		{"any bootloader", BootMode, nil, BootMode, nil},
+		{"timeout", FirmwareMode, []Mode{BootMode}, 0, ErrWaitTimeout},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e.mode = test.mode
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			h, err := Wait(ctx, sel, test.modes...)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Wait error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			defer h.Close()
+			if h.Mode() != test.want {
+				t.Errorf("Wait returned a %s handle, want %s", h.Mode(), test.want)
+			}
+		})
+	}
+}
+
+// deniedBackend is an emulator that's found but can't be opened, like a
+// controller without udev permissions.
+type deniedBackend struct {
+	*Emulator
+}
+
+func (b deniedBackend) Open(vid, pid ID, sel Selector) (Transport, error) {
+	return nil, os.ErrPermission
+}
+
+// A wait that times out reports why the controller couldn't be opened.
+func TestEmulatorWaitDenied(t *testing.T) {
+	useEmulator(t)
+	DefaultBackend = deniedBackend{NewEmulator()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := Wait(ctx, Selector{}, FirmwareMode)
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("Wait error %v, want %v", err, ErrWaitTimeout)
+	}
+	if !strings.Contains(err.Error(), os.ErrPermission.Error()) {
+		t.Errorf("Wait error %q doesn't say why the controller couldn't be opened", err)
+	}
+}
+
+func TestEmulatorWaitReenumerate(t *testing.T) {
+	e := useEmulator(t)
+	sel := Selector{Serial: "EMULATOR"}
+
+	c, err := Open(sel)
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+	if err := c.EnterBoot(); err != nil {
+		t.Fatalf("EnterBoot: %s", err)
+	}
+	c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	b, err := WaitBootloader(ctx, sel)
+	if err != nil {
+		t.Fatalf("WaitBootloader: %s", err)
+	}
+	if err := b.ExitBoot(); err != nil {
+		t.Fatalf("ExitBoot: %s", err)
+	}
+	b.Close()
+
+	if _, err := WaitController(ctx, sel); err != nil {
+		t.Fatalf("WaitController: %s", err)
+	}
+	if e.Info().Address != 3 {
+		t.Errorf("address is %d after two re-enumerations, want 3", e.Info().Address)
+	}
+}
+
+func TestEmulatorControllerFlash(t *testing.T) {
+	e := useEmulator(t)
+	img, _ := firmware.ParseBin(bytes.Repeat([]byte{0x5a}, 2*firmware.PageSize))
+
+	c, err := Open(Selector{Serial: "EMULATOR"})
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+	defer c.Close()
+
+	var pages int
+	if err := c.Flash(context.Background(), img, func(Progress) { pages++ }); err != nil {
+		t.Fatalf("Flash: %s", err)
+	}
+	if pages != img.Pages() {
+		t.Errorf("progress called %d times, want %d", pages, img.Pages())
+	}
+	if e.Mode() != FirmwareMode {
+		t.Errorf("mode after Flash is %s", e.Mode())
+	}
+	if f := e.Flash(); !bytes.Equal(f[:len(img)], img) {
+		t.Error("flash contents don't match the image")
+	}
+}
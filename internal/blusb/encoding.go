@@ -0,0 +1,281 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSON and YAML use the same document types.  Keycodes and modifiers are
+// encoded by name.  The marshalers on Layers and Macros take precedence
+// over their CSV text marshalers.
+
+// rows returns the layer matrix as rows of keycodes.
+func (l Layer) rows() [][]Keycode {
+	rows := make([][]Keycode, len(l.Matrix))
+	for r := range l.Matrix {
+		rows[r] = append([]Keycode{}, l.Matrix[r][:]...)
+	}
+
+	return rows
+}
+
+// setRows sets the layer matrix from rows of keycodes.
+func (l *Layer) setRows(rows [][]Keycode) error {
+	if len(rows) != MatrixRows {
+		return fmt.Errorf("%w: %d rows instead of %d", ErrInvalidLayer, len(rows), MatrixRows)
+	}
+	for r := range rows {
+		if len(rows[r]) != MatrixCols {
+			return fmt.Errorf("%w: row %d has %d keys instead of %d", ErrInvalidLayer, r, len(rows[r]), MatrixCols)
+		}
+		copy(l.Matrix[r][:], rows[r])
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the layer as an array of rows of key names.
+func (l Layer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.rows())
+}
+
+// UnmarshalJSON decodes an array of rows of key names.
+func (l *Layer) UnmarshalJSON(b []byte) error {
+	var rows [][]Keycode
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return err
+	}
+
+	return l.setRows(rows)
+}
+
+// MarshalYAML encodes the layer as a sequence of rows of key names with
+// each row on one line.
+func (l Layer) MarshalYAML() (interface{}, error) {
+	var n yaml.Node
+	if err := n.Encode(l.rows()); err != nil {
+		return nil, err
+	}
+	for _, row := range n.Content {
+		row.Style = yaml.FlowStyle
+	}
+
+	return &n, nil
+}
+
+// UnmarshalYAML decodes a sequence of rows of key names.
+func (l *Layer) UnmarshalYAML(n *yaml.Node) error {
+	var rows [][]Keycode
+	if err := n.Decode(&rows); err != nil {
+		return err
+	}
+
+	return l.setRows(rows)
+}
+
+// MarshalJSON encodes the layers as an array of layers.
+func (ls Layers) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Layer(ls))
+}
+
+// UnmarshalJSON decodes an array of layers.
+func (ls *Layers) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*[]Layer)(ls))
+}
+
+// MarshalYAML encodes the layers as a sequence of layers.
+func (ls Layers) MarshalYAML() (interface{}, error) {
+	return []Layer(ls), nil
+}
+
+// UnmarshalYAML decodes a sequence of layers.
+func (ls *Layers) UnmarshalYAML(n *yaml.Node) error {
+	return n.Decode((*[]Layer)(ls))
+}
+
+// macroDoc is the JSON and YAML representation of a macro.
+type macroDoc struct {
+	Mods     Modifier  `json:"mods" yaml:"mods"`
+	Reserved uint8     `json:"reserved,omitempty" yaml:"reserved,omitempty"`
+	Keys     []Keycode `json:"keys" yaml:"keys,flow"`
+}
+
+func (m Macro) doc() macroDoc {
+	d := macroDoc{Mods: m.Mods, Reserved: m.Reserved, Keys: []Keycode{}}
+	// Trailing empty keys are left out.
+	n := len(m.Key)
+	for n > 0 && m.Key[n-1] == 0 {
+		n--
+	}
+	d.Keys = append(d.Keys, m.Key[:n]...)
+
+	return d
+}
+
+func (m *Macro) setDoc(d macroDoc) error {
+	if len(d.Keys) > len(m.Key) {
+		return fmt.Errorf("%w: more than %d keys", ErrInvalidMacro, len(m.Key))
+	}
+
+	*m = Macro{Mods: d.Mods, Reserved: d.Reserved}
+	for i, k := range d.Keys {
+		if k > 0xff {
+			return fmt.Errorf("%w: %q isn't a key", ErrInvalidMacro, k)
+		}
+		m.Key[i] = k
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the macro as an object with its modifier key names
+// and an array of key names.
+func (m Macro) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.doc())
+}
+
+// UnmarshalJSON decodes a macro object.
+func (m *Macro) UnmarshalJSON(b []byte) error {
+	var d macroDoc
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+
+	return m.setDoc(d)
+}
+
+// MarshalYAML encodes the macro as a mapping with its modifier key names
+// and a sequence of key names.
+func (m Macro) MarshalYAML() (interface{}, error) {
+	return m.doc(), nil
+}
+
+// UnmarshalYAML decodes a macro mapping.
+func (m *Macro) UnmarshalYAML(n *yaml.Node) error {
+	var d macroDoc
+	if err := n.Decode(&d); err != nil {
+		return err
+	}
+
+	return m.setDoc(d)
+}
+
+func (ms *Macros) setSlice(s []Macro) error {
+	if len(s) > len(ms) {
+		return fmt.Errorf("%w: more than %d macros", ErrInvalidMacro, len(ms))
+	}
+
+	*ms = Macros{}
+	copy(ms[:], s)
+
+	return nil
+}
+
+// MarshalJSON encodes the macro table as an array of macros.
+func (ms Macros) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ms[:])
+}
+
+// UnmarshalJSON decodes an array of macros.  Missing macros at the end of
+// the table are empty.
+func (ms *Macros) UnmarshalJSON(b []byte) error {
+	var s []Macro
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return ms.setSlice(s)
+}
+
+// MarshalYAML encodes the macro table as a sequence of macros.
+func (ms Macros) MarshalYAML() (interface{}, error) {
+	return ms[:], nil
+}
+
+// UnmarshalYAML decodes a sequence of macros.  Missing macros at the end of
+// the table are empty.
+func (ms *Macros) UnmarshalYAML(n *yaml.Node) error {
+	var s []Macro
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return ms.setSlice(s)
+}
+
+// settingsDoc is the JSON and YAML representation of settings.
+type settingsDoc struct {
+	Brightness *Brightness `json:"brightness,omitempty" yaml:"brightness,omitempty"`
+	Debounce   string      `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+	Macros     *Macros     `json:"macros,omitempty" yaml:"macros,omitempty"`
+	Layers     Layers      `json:"layers,omitempty" yaml:"layers,omitempty"`
+}
+
+func (s Settings) doc() settingsDoc {
+	d := settingsDoc{Brightness: s.Brightness, Macros: s.Macros, Layers: s.Layers}
+	if s.Debounce > 0 {
+		d.Debounce = s.Debounce.String()
+	}
+
+	return d
+}
+
+func (s *Settings) setDoc(d settingsDoc) error {
+	*s = Settings{Brightness: d.Brightness, Macros: d.Macros, Layers: d.Layers}
+
+	if s.Brightness != nil && (s.Brightness.USB > 255 || s.Brightness.Bluetooth > 255) {
+		return ErrInvalidBrightness
+	}
+	if d.Debounce != "" {
+		var err error
+		s.Debounce, err = time.ParseDuration(d.Debounce)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidConfig, err)
+		}
+		if s.Debounce < 1*time.Millisecond || s.Debounce > 255*time.Millisecond {
+			return ErrInvalidDebounceDur
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the non-zero settings as an object.  The debounce
+// duration is a string like "5ms".
+func (s Settings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.doc())
+}
+
+// UnmarshalJSON decodes a settings object.  Missing settings are left zero
+// so they aren't changed.
+func (s *Settings) UnmarshalJSON(b []byte) error {
+	var d settingsDoc
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+
+	return s.setDoc(d)
+}
+
+// MarshalYAML encodes the non-zero settings as a mapping.
+func (s Settings) MarshalYAML() (interface{}, error) {
+	return s.doc(), nil
+}
+
+// UnmarshalYAML decodes a settings mapping.  Missing settings are left zero
+// so they aren't changed.
+func (s *Settings) UnmarshalYAML(n *yaml.Node) error {
+	var d settingsDoc
+	if err := n.Decode(&d); err != nil {
+		return err
+	}
+
+	return s.setDoc(d)
+}
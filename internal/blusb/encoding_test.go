@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Documents are encoded and decoded with both JSON and YAML.
+var encodings = []struct {
+	name      string
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}{
+	{"JSON", json.Marshal, json.Unmarshal},
+	{"YAML", yaml.Marshal, yaml.Unmarshal},
+}
+
+func testMacros() Macros {
+	return Macros{
+		{Mods: LCtrl | LAlt, Key: [6]Keycode{0x4c}},
+		{Key: [6]Keycode{2: 0x04, 4: 0x05}},
+		{Mods: LShift, Reserved: 0x5a, Key: [6]Keycode{0x04, 0xe0}},
+		23: {Reserved: 1},
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	ls := testLayers(3)
+	ls[0].Matrix[0][0] = ModifierKey(LCtrl | RAlt)
+	ms := testMacros()
+
+	for _, enc := range encodings {
+		data, err := enc.marshal(ls)
+		if err != nil {
+			t.Fatalf("%s: marshal layers: %s", enc.name, err)
+		}
+		var gotLayers Layers
+		if err := enc.unmarshal(data, &gotLayers); err != nil {
+			t.Fatalf("%s: unmarshal layers: %s", enc.name, err)
+		}
+		if !reflect.DeepEqual(gotLayers, ls) {
+			t.Errorf("%s: layers differ:\n%s", enc.name, DiffLayers(ls, gotLayers))
+		}
+
+		data, err = enc.marshal(ms)
+		if err != nil {
+			t.Fatalf("%s: marshal macros: %s", enc.name, err)
+		}
+		var gotMacros Macros
+		if err := enc.unmarshal(data, &gotMacros); err != nil {
+			t.Fatalf("%s: unmarshal macros: %s", enc.name, err)
+		}
+		if gotMacros != ms {
+			t.Errorf("%s: macros differ:\n%s", enc.name, DiffMacros(ms, gotMacros))
+		}
+	}
+}
+
+func TestEncodingNames(t *testing.T) {
+	ls := Layers{Layer{}}
+	ls[0].Matrix[0][0] = ModifierKey(LCtrl | LShift)
+	ls[0].Matrix[0][1] = 0x29
+	ms := Macros{{Mods: LCtrl, Key: [6]Keycode{0x04, 0, 0x05}}}
+
+	data, err := json.Marshal(ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[[["LCTRL|LSHIFT","ESC","NONE",`; !strings.HasPrefix(string(data), want) {
+		t.Errorf("JSON layers %.40s..., want prefix %s", data, want)
+	}
+	data, err = json.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"mods":"LCTRL","keys":["A","NONE","B"]},{"mods":"NONE","keys":[]},`; !strings.HasPrefix(string(data), want) {
+		t.Errorf("JSON macros %.80s..., want prefix %s", data, want)
+	}
+
+	data, err = yaml.Marshal(ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- - [LCTRL|LSHIFT, ESC, NONE,"; !strings.HasPrefix(string(data), want) {
+		t.Errorf("YAML layers %.40s..., want prefix %s", data, want)
+	}
+	data, err = yaml.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- mods: LCTRL\n  keys: [A, NONE, B]\n"; !strings.HasPrefix(string(data), want) {
+		t.Errorf("YAML macros %.80s..., want prefix %q", data, want)
+	}
+}
+
+// Missing macros at the end of the table are empty.
+func TestEncodingShortMacros(t *testing.T) {
+	for _, enc := range encodings {
+		ms := testMacros()
+		if err := enc.unmarshal([]byte(`[{"mods": "RGUI", "keys": ["TAB"]}]`), &ms); err != nil {
+			t.Fatalf("%s: %s", enc.name, err)
+		}
+		if want := (Macros{{Mods: RGUI, Key: [6]Keycode{0x2b}}}); ms != want {
+			t.Errorf("%s: macros are %v, want %v", enc.name, ms, want)
+		}
+	}
+}
+
+func TestEncodingErrors(t *testing.T) {
+	row := `["` + strings.TrimSuffix(strings.Repeat(`A","`, MatrixCols), `","`) + `"]`
+	rows := func(n int, r string) string {
+		return "[" + strings.TrimSuffix(strings.Repeat(r+",", n), ",") + "]"
+	}
+
+	tests := []struct {
+		name string
+		data string
+		v    interface{}
+		err  error
+	}{
+		{"short layer", "[" + rows(MatrixRows-1, row) + "]", &Layers{}, ErrInvalidLayer},
+		{"short row", "[" + rows(MatrixRows, `["A"]`) + "]", &Layers{}, ErrInvalidLayer},
+		{"unknown key", `[[["NOTAKEY"]]]`, &Layers{}, ErrInvalidKeycode},
+		{"too many keys", `[{"mods": "NONE", "keys": ["A", "B", "C", "D", "E", "F", "G"]}]`, &Macros{}, ErrInvalidMacro},
+		{"modifier key", `[{"mods": "NONE", "keys": ["LCTRL"]}]`, &Macros{}, ErrInvalidMacro},
+		{"unknown modifier", `[{"mods": "HYPER", "keys": []}]`, &Macros{}, ErrInvalidModifier},
+		{"too many macros", rows(numMacros+1, `{"mods": "NONE", "keys": []}`), &Macros{}, ErrInvalidMacro},
+	}
+
+	for _, enc := range encodings {
+		for _, test := range tests {
+			// JSON is valid YAML.
+			if err := enc.unmarshal([]byte(test.data), test.v); !errors.Is(err, test.err) {
+				t.Errorf("%s: %s: error %v, want %v", enc.name, test.name, err, test.err)
+			}
+		}
+	}
+}
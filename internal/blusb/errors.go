@@ -11,4 +11,18 @@ var (
 	ErrInvalidBrightness  = errors.New("brightness value must be between 0 and 255")
 	ErrInvalidDebounceDur = errors.New("debounce duration must be between 1ms and 255ms")
 	ErrControllerNotFound = errors.New("blusb controller not found")
+	ErrWaitTimeout        = errors.New("timed out waiting for controller")
+	ErrInvalidPage        = errors.New("firmware page is too large")
+	ErrNoBackend          = errors.New("no device backend available")
+	ErrInvalidSelector    = errors.New("invalid device selector")
+	ErrInvalidID          = errors.New("invalid USB ID")
+	ErrInvalidKeycode     = errors.New("invalid keycode")
+	ErrInvalidModifier    = errors.New("invalid modifier")
+	ErrInvalidKeymap      = errors.New("invalid keymap")
+	ErrInvalidMacro       = errors.New("invalid macro")
+	ErrInvalidLayer       = errors.New("invalid layer")
+	ErrInvalidConfig      = errors.New("invalid config")
+	ErrVerify             = errors.New("read back doesn't match what was set")
+	ErrInvalidBackup      = errors.New("invalid backup")
+	ErrBackupChecksum     = errors.New("backup checksum mismatch")
 )
@@ -4,6 +4,16 @@
 
 package blusb
 
+import (
+	"context"
+	"time"
+
+	"github.com/ebarkie/goblusb/internal/firmware"
+)
+
+// How long to wait for the bootloader to enumerate after entering it
+var BootWait = 10 * time.Second
+
 // EnterBoot signals the firmware to enter the bootloader.
 func (c Controller) EnterBoot() error {
 	data := make([]byte, 8)
@@ -11,13 +21,50 @@ func (c Controller) EnterBoot() error {
 	return c.setControlReport(data)
 }
 
-// ExitBoot signals the bootloader to exit and boot the firmware.
-func (c Controller) ExitBoot() error {
-	data := make([]byte, 8)
-	data[0] = bootExit
-	return c.setControlReport(data)
+// UpdateFirmware flashes the controller with the Intel HEX or raw binary
+// firmware image in filename.  The image is validated before anything is
+// sent, then the controller is put into the bootloader, sent the image one
+// page at a time, and rebooted into the new firmware.
+//
+// The controller re-enumerates during the update so the receiver should be
+// closed and reopened afterwards.
+func (c Controller) UpdateFirmware(filename string) error {
+	return c.UpdateFirmwareContext(context.Background(), filename, nil)
 }
 
-func (c Controller) UpdateFirmware(filename string) error {
-	return nil
+// UpdateFirmwareContext is like UpdateFirmware but calls progress after each
+// page is flashed.  If the context is canceled it stops between pages and
+// returns the context error, which leaves the controller in the bootloader.
+func (c Controller) UpdateFirmwareContext(ctx context.Context, filename string, progress ProgressFunc) error {
+	img, err := firmware.Load(filename)
+	if err != nil {
+		return err
+	}
+
+	return c.Flash(ctx, img, progress)
+}
+
+// Flash reboots the controller into the bootloader and writes the image,
+// calling progress after each page.  If the context is canceled it stops
+// between pages and returns the context error, which leaves the controller in
+// the bootloader.
+func (c Controller) Flash(ctx context.Context, img firmware.Image, progress ProgressFunc) error {
+	if err := c.EnterBoot(); err != nil {
+		return err
+	}
+	if c.SkipSets {
+		// Nothing was sent so the bootloader won't be showing up.
+		return nil
+	}
+
+	// The bootloader comes back on the same port with a new address.
+	waitCtx, cancel := context.WithTimeout(ctx, BootWait)
+	defer cancel()
+	b, err := WaitBootloader(waitCtx, Selector{Port: c.Info().Port})
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	return b.Flash(ctx, img, progress)
 }
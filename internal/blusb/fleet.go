@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"sync"
+)
+
+// Result is the outcome of an operation on one controller.
+type Result struct {
+	Device DeviceInfo
+	Err    error
+}
+
+// ForEach opens every attached controller running the firmware and calls fn
+// for each of them concurrently.  It returns one result per controller in
+// the same order as List.
+func ForEach(ctx context.Context, fn func(context.Context, Controller) error) ([]Result, error) {
+	infos, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, i := range infos {
+		if i.Mode == FirmwareMode {
+			results = append(results, Result{Device: i})
+		}
+	}
+	if len(results) < 1 {
+		return nil, ErrControllerNotFound
+	}
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(r *Result) {
+			defer wg.Done()
+
+			c, err := Open(Selector{Bus: r.Device.Bus, Address: r.Device.Address})
+			if err != nil {
+				r.Err = err
+				return
+			}
+			defer c.Close()
+
+			r.Err = fn(ctx, c)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
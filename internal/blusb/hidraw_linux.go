@@ -0,0 +1,213 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build linux && (386 || amd64 || arm || arm64 || riscv64)
+
+package blusb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// hidraw ioctl requests.  The _IOC bit layout is the asm-generic one which
+// is why the build is limited to the architectures that use it.
+const (
+	iocRead  = 2
+	iocWrite = 1
+
+	hidiocSFeature = 0x06
+	hidiocGFeature = 0x07
+)
+
+func hidioc(nr, size int) uintptr {
+	return uintptr((iocRead|iocWrite)<<30 | size<<16 | 'H'<<8 | nr)
+}
+
+// Hidraw is the Linux hidraw backend which sends feature reports through
+// /dev/hidraw ioctls.  It's pure Go and works alongside the kernel HID
+// driver so nothing has to be detached or claimed.
+var Hidraw Backend = hidrawBackend{}
+
+func init() {
+	Backends["hidraw"] = Hidraw
+}
+
+type hidrawBackend struct{}
+
+// hidrawDev represents a hidraw node and the USB device it belongs to.
+type hidrawDev struct {
+	node string // Device node, e.g. /dev/hidraw0
+	usb  string // sysfs USB device directory
+	intf int    // USB interface number
+
+	vid, pid ID
+}
+
+// sysfsAttr reads a sysfs attribute and trims the trailing newline.
+func sysfsAttr(dir, name string) string {
+	b, _ := os.ReadFile(filepath.Join(dir, name))
+	return strings.TrimSpace(string(b))
+}
+
+func sysfsHex(dir, name string) (int, error) {
+	u, err := strconv.ParseUint(sysfsAttr(dir, name), 16, 16)
+	return int(u), err
+}
+
+// hidrawDevices returns every hidraw node that belongs to a USB device.
+func hidrawDevices() ([]hidrawDev, error) {
+	matches, err := filepath.Glob("/sys/class/hidraw/hidraw*")
+	if err != nil {
+		return nil, err
+	}
+
+	var devs []hidrawDev
+	for _, m := range matches {
+		// The hidraw device is a child of the HID device, which is a child
+		// of the USB interface, which is a child of the USB device.
+		hid, err := filepath.EvalSymlinks(filepath.Join(m, "device"))
+		if err != nil {
+			continue
+		}
+		intf := filepath.Dir(hid)
+		usb := filepath.Dir(intf)
+
+		vid, err := sysfsHex(usb, "idVendor")
+		if err != nil {
+			// Not USB, e.g. Bluetooth or I2C.
+			continue
+		}
+		pid, err := sysfsHex(usb, "idProduct")
+		if err != nil {
+			continue
+		}
+		n, err := sysfsHex(intf, "bInterfaceNumber")
+		if err != nil {
+			continue
+		}
+
+		devs = append(devs, hidrawDev{
+			node: filepath.Join("/dev", filepath.Base(m)),
+			usb:  usb,
+			intf: n,
+			vid:  ID(vid),
+			pid:  ID(pid),
+		})
+	}
+
+	return devs, nil
+}
+
+func (d hidrawDev) info() DeviceInfo {
+	bus, _ := strconv.Atoi(sysfsAttr(d.usb, "busnum"))
+	addr, _ := strconv.Atoi(sysfsAttr(d.usb, "devnum"))
+
+	return DeviceInfo{
+		Bus:     bus,
+		Address: addr,
+		Port:    filepath.Base(d.usb),
+		Serial:  sysfsAttr(d.usb, "serial"),
+	}
+}
+
+// matchDevices returns the default interface hidraw nodes matching vid, pid,
+// and the selector.
+func matchDevices(vid, pid ID, sel Selector) ([]hidrawDev, error) {
+	devs, err := hidrawDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []hidrawDev
+	for _, d := range devs {
+		if d.vid == vid && d.pid == pid && d.intf == 0 && sel.Match(d.info()) {
+			matched = append(matched, d)
+		}
+	}
+
+	return matched, nil
+}
+
+// List returns every device matching vid and pid.
+func (hidrawBackend) List(vid, pid ID) ([]DeviceInfo, error) {
+	devs, err := matchDevices(vid, pid, Selector{})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DeviceInfo, len(devs))
+	for i := range devs {
+		infos[i] = devs[i].info()
+	}
+
+	return infos, nil
+}
+
+// Open opens the hidraw node for the default interface of the device.
+func (hidrawBackend) Open(vid, pid ID, sel Selector) (Transport, error) {
+	devs, err := matchDevices(vid, pid, sel)
+	if err != nil {
+		return nil, err
+	}
+	if len(devs) < 1 {
+		return nil, ErrControllerNotFound
+	}
+
+	f, err := os.OpenFile(devs[0].node, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return hidrawTransport{f: f, dev: devs[0]}, nil
+}
+
+// hidrawTransport holds an opened hidraw node.
+type hidrawTransport struct {
+	f   *os.File
+	dev hidrawDev
+}
+
+func (t hidrawTransport) ioctl(req uintptr, b []byte) (int, error) {
+	n, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.f.Fd(), req, uintptr(unsafe.Pointer(&b[0])))
+	if errno != 0 {
+		return 0, errno
+	}
+
+	return int(n), nil
+}
+
+// GetFeature reads feature report id.  The report ID goes in the first byte
+// of the buffer and the kernel replaces it with the device data, so nothing
+// has to be stripped.
+func (t hidrawTransport) GetFeature(id byte, b []byte) (int, error) {
+	b[0] = id
+	return t.ioctl(hidioc(hidiocGFeature, len(b)), b)
+}
+
+func (t hidrawTransport) SetFeature(b []byte) error {
+	_, err := t.ioctl(hidioc(hidiocSFeature, len(b)), b)
+	return err
+}
+
+func (t hidrawTransport) Close() error {
+	return t.f.Close()
+}
+
+func (t hidrawTransport) String() string {
+	i := t.dev.info()
+
+	return fmt.Sprintf("Bus: %d Address: %d Manufacturer: %s (%s) Product: %s (%s)",
+		i.Bus, i.Address, sysfsAttr(t.dev.usb, "manufacturer"), t.dev.vid,
+		sysfsAttr(t.dev.usb, "product"), t.dev.pid)
+}
+
+func (t hidrawTransport) Info() DeviceInfo {
+	return t.dev.info()
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Modifier represents a set of modifier keys as bits.
+type Modifier uint8
+
+// Modifier keys
+const (
+	LCtrl Modifier = 1 << iota
+	LShift
+	LAlt
+	LGUI
+	RCtrl
+	RShift
+	RAlt
+	RGUI
+)
+
+var modifierNames = [...]string{"LCTRL", "LSHIFT", "LALT", "LGUI", "RCTRL", "RSHIFT", "RALT", "RGUI"}
+
+func (m Modifier) String() string {
+	if m == 0 {
+		return "NONE"
+	}
+
+	var names []string
+	for i, name := range modifierNames {
+		if m&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// ParseModifier parses modifier key names separated by "|" or "+", e.g.
+// "LCTRL|LSHIFT".
+func ParseModifier(s string) (Modifier, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "NONE" {
+		return 0, nil
+	}
+
+	var m Modifier
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == '+' }) {
+		i := indexOf(modifierNames[:], strings.TrimSpace(name))
+		if i < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidModifier, s)
+		}
+		m |= 1 << i
+	}
+	if m == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidModifier, s)
+	}
+
+	return m, nil
+}
+
+// MarshalText encodes the modifier keys as their names.
+func (m Modifier) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes modifier key names.
+func (m *Modifier) UnmarshalText(text []byte) (err error) {
+	*m, err = ParseModifier(string(text))
+	return
+}
+
+func indexOf(names []string, name string) int {
+	for i := range names {
+		if names[i] == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Keycode represents a layer key.  The low byte is a HID Keyboard/Keypad
+// page usage and the high byte indicates how the firmware interprets it.
+//
+// A high byte of 0x01 means the low byte is a set of Modifier bits, which is
+// how the modifier keys are stored in layers.  A keycode used in a macro is
+// always a plain usage.
+type Keycode uint16
+
+// Keycode kinds stored in the high byte
+const (
+	kindUsage    = 0x00
+	kindModifier = 0x01
+)
+
+// ModifierKey returns the layer keycode for modifier keys.
+func ModifierKey(m Modifier) Keycode {
+	return Keycode(kindModifier)<<8 | Keycode(m)
+}
+
+// IsModifier indicates if the keycode is a modifier key.
+func (k Keycode) IsModifier() bool {
+	return k>>8 == kindModifier && k&0xff != 0
+}
+
+// Modifier returns the modifier bits of a modifier key, or zero if it isn't
+// one.
+func (k Keycode) Modifier() Modifier {
+	if !k.IsModifier() {
+		return 0
+	}
+
+	return Modifier(k)
+}
+
+// Usage returns the HID usage of the keycode.
+func (k Keycode) Usage() uint8 {
+	return uint8(k)
+}
+
+func (k Keycode) String() string {
+	switch {
+	case k.IsModifier():
+		return k.Modifier().String()
+	case k>>8 == kindUsage && usageNames[k] != "":
+		return usageNames[k]
+	}
+
+	return fmt.Sprintf("0x%02X", uint16(k))
+}
+
+// MarshalText encodes the keycode as its name.
+func (k Keycode) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes a keycode name.
+func (k *Keycode) UnmarshalText(text []byte) (err error) {
+	*k, err = ParseKeycode(string(text))
+	return
+}
+
+// ParseKeycode parses a keycode name as returned by String, e.g. "TAB",
+// "KP_1", or "LCTRL".  Hexadecimal values starting with "0x" are accepted
+// for anything that doesn't have a name.
+func ParseKeycode(s string) (Keycode, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	if k, ok := usageValues[s]; ok {
+		return k, nil
+	}
+	if m, err := ParseModifier(s); err == nil && m != 0 {
+		return ModifierKey(m), nil
+	}
+	if strings.HasPrefix(s, "0X") {
+		u, err := strconv.ParseUint(s[2:], 16, 16)
+		if err == nil {
+			return Keycode(u), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidKeycode, s)
+}
+
+// HID Keyboard/Keypad page (0x07) usage names.  Reserved usages are left
+// empty.
+var usageNames = [256]string{
+	0x00: "NONE",
+	0x01: "ERR_ROLLOVER",
+	0x02: "POST_FAIL",
+	0x03: "ERR_UNDEFINED",
+	0x04: "A",
+	0x05: "B",
+	0x06: "C",
+	0x07: "D",
+	0x08: "E",
+	0x09: "F",
+	0x0a: "G",
+	0x0b: "H",
+	0x0c: "I",
+	0x0d: "J",
+	0x0e: "K",
+	0x0f: "L",
+	0x10: "M",
+	0x11: "N",
+	0x12: "O",
+	0x13: "P",
+	0x14: "Q",
+	0x15: "R",
+	0x16: "S",
+	0x17: "T",
+	0x18: "U",
+	0x19: "V",
+	0x1a: "W",
+	0x1b: "X",
+	0x1c: "Y",
+	0x1d: "Z",
+	0x1e: "1",
+	0x1f: "2",
+	0x20: "3",
+	0x21: "4",
+	0x22: "5",
+	0x23: "6",
+	0x24: "7",
+	0x25: "8",
+	0x26: "9",
+	0x27: "0",
+	0x28: "ENTER",
+	0x29: "ESC",
+	0x2a: "BSPACE",
+	0x2b: "TAB",
+	0x2c: "SPACE",
+	0x2d: "MINUS",
+	0x2e: "EQUAL",
+	0x2f: "LBRACKET",
+	0x30: "RBRACKET",
+	0x31: "BSLASH",
+	0x32: "NONUS_HASH",
+	0x33: "SCOLON",
+	0x34: "QUOTE",
+	0x35: "GRAVE",
+	0x36: "COMMA",
+	0x37: "DOT",
+	0x38: "SLASH",
+	0x39: "CAPSLOCK",
+	0x3a: "F1",
+	0x3b: "F2",
+	0x3c: "F3",
+	0x3d: "F4",
+	0x3e: "F5",
+	0x3f: "F6",
+	0x40: "F7",
+	0x41: "F8",
+	0x42: "F9",
+	0x43: "F10",
+	0x44: "F11",
+	0x45: "F12",
+	0x46: "PSCREEN",
+	0x47: "SCROLLLOCK",
+	0x48: "PAUSE",
+	0x49: "INSERT",
+	0x4a: "HOME",
+	0x4b: "PGUP",
+	0x4c: "DELETE",
+	0x4d: "END",
+	0x4e: "PGDOWN",
+	0x4f: "RIGHT",
+	0x50: "LEFT",
+	0x51: "DOWN",
+	0x52: "UP",
+	0x53: "NUMLOCK",
+	0x54: "KP_SLASH",
+	0x55: "KP_ASTERISK",
+	0x56: "KP_MINUS",
+	0x57: "KP_PLUS",
+	0x58: "KP_ENTER",
+	0x59: "KP_1",
+	0x5a: "KP_2",
+	0x5b: "KP_3",
+	0x5c: "KP_4",
+	0x5d: "KP_5",
+	0x5e: "KP_6",
+	0x5f: "KP_7",
+	0x60: "KP_8",
+	0x61: "KP_9",
+	0x62: "KP_0",
+	0x63: "KP_DOT",
+	0x64: "NONUS_BSLASH",
+	0x65: "APPLICATION",
+	0x66: "POWER",
+	0x67: "KP_EQUAL",
+	0x68: "F13",
+	0x69: "F14",
+	0x6a: "F15",
+	0x6b: "F16",
+	0x6c: "F17",
+	0x6d: "F18",
+	0x6e: "F19",
+	0x6f: "F20",
+	0x70: "F21",
+	0x71: "F22",
+	0x72: "F23",
+	0x73: "F24",
+	0x74: "EXECUTE",
+	0x75: "HELP",
+	0x76: "MENU",
+	0x77: "SELECT",
+	0x78: "STOP",
+	0x79: "AGAIN",
+	0x7a: "UNDO",
+	0x7b: "CUT",
+	0x7c: "COPY",
+	0x7d: "PASTE",
+	0x7e: "FIND",
+	0x7f: "MUTE",
+	0x80: "VOLUP",
+	0x81: "VOLDOWN",
+	0x82: "LOCKING_CAPS",
+	0x83: "LOCKING_NUM",
+	0x84: "LOCKING_SCROLL",
+	0x85: "KP_COMMA",
+	0x86: "KP_EQUAL_AS400",
+	0x87: "INT1",
+	0x88: "INT2",
+	0x89: "INT3",
+	0x8a: "INT4",
+	0x8b: "INT5",
+	0x8c: "INT6",
+	0x8d: "INT7",
+	0x8e: "INT8",
+	0x8f: "INT9",
+	0x90: "LANG1",
+	0x91: "LANG2",
+	0x92: "LANG3",
+	0x93: "LANG4",
+	0x94: "LANG5",
+	0x95: "LANG6",
+	0x96: "LANG7",
+	0x97: "LANG8",
+	0x98: "LANG9",
+	0x99: "ALT_ERASE",
+	0x9a: "SYSREQ",
+	0x9b: "CANCEL",
+	0x9c: "CLEAR",
+	0x9d: "PRIOR",
+	0x9e: "RETURN",
+	0x9f: "SEPARATOR",
+	0xa0: "OUT",
+	0xa1: "OPER",
+	0xa2: "CLEAR_AGAIN",
+	0xa3: "CRSEL",
+	0xa4: "EXSEL",
+	0xb0: "KP_00",
+	0xb1: "KP_000",
+	0xb2: "THOUSANDS_SEP",
+	0xb3: "DECIMAL_SEP",
+	0xb4: "CURRENCY_UNIT",
+	0xb5: "CURRENCY_SUBUNIT",
+	0xb6: "KP_LPAREN",
+	0xb7: "KP_RPAREN",
+	0xb8: "KP_LBRACE",
+	0xb9: "KP_RBRACE",
+	0xba: "KP_TAB",
+	0xbb: "KP_BSPACE",
+	0xbc: "KP_A",
+	0xbd: "KP_B",
+	0xbe: "KP_C",
+	0xbf: "KP_D",
+	0xc0: "KP_E",
+	0xc1: "KP_F",
+	0xc2: "KP_XOR",
+	0xc3: "KP_CARET",
+	0xc4: "KP_PERCENT",
+	0xc5: "KP_LT",
+	0xc6: "KP_GT",
+	0xc7: "KP_AMP",
+	0xc8: "KP_AMPAMP",
+	0xc9: "KP_PIPE",
+	0xca: "KP_PIPEPIPE",
+	0xcb: "KP_COLON",
+	0xcc: "KP_HASH",
+	0xcd: "KP_SPACE",
+	0xce: "KP_AT",
+	0xcf: "KP_BANG",
+	0xd0: "KP_MEM_STORE",
+	0xd1: "KP_MEM_RECALL",
+	0xd2: "KP_MEM_CLEAR",
+	0xd3: "KP_MEM_ADD",
+	0xd4: "KP_MEM_SUB",
+	0xd5: "KP_MEM_MUL",
+	0xd6: "KP_MEM_DIV",
+	0xd7: "KP_PLUSMINUS",
+	0xd8: "KP_CLEAR",
+	0xd9: "KP_CLEAR_ENTRY",
+	0xda: "KP_BINARY",
+	0xdb: "KP_OCTAL",
+	0xdc: "KP_DECIMAL",
+	0xdd: "KP_HEX",
+	// The modifier usages are spelled out so they don't collide with the
+	// modifier key names.
+	0xe0: "LEFTCONTROL",
+	0xe1: "LEFTSHIFT",
+	0xe2: "LEFTALT",
+	0xe3: "LEFTGUI",
+	0xe4: "RIGHTCONTROL",
+	0xe5: "RIGHTSHIFT",
+	0xe6: "RIGHTALT",
+	0xe7: "RIGHTGUI",
+}
+
+// usageValues maps usage names back to keycodes.
+var usageValues = func() map[string]Keycode {
+	m := map[string]Keycode{}
+	for i, name := range usageNames {
+		if name != "" {
+			m[name] = Keycode(i)
+		}
+	}
+
+	return m
+}()
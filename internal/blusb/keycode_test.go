@@ -0,0 +1,86 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeycodeRoundTrip(t *testing.T) {
+	var kcs []Keycode
+	// Every usage, named or not.
+	for u := 0; u <= 0xff; u++ {
+		kcs = append(kcs, Keycode(u))
+	}
+	// Every modifier key combination.
+	for m := 1; m <= 0xff; m++ {
+		kcs = append(kcs, ModifierKey(Modifier(m)))
+	}
+	// Other kinds the firmware may use.
+	kcs = append(kcs, 0x0100, 0x0204, 0xffff)
+
+	for _, kc := range kcs {
+		s := kc.String()
+		got, err := ParseKeycode(s)
+		if err != nil {
+			t.Errorf("%#04x: ParseKeycode(%q): %s", uint16(kc), s, err)
+			continue
+		}
+		if got != kc {
+			t.Errorf("%#04x: ParseKeycode(%q) = %#04x", uint16(kc), s, uint16(got))
+		}
+	}
+}
+
+func TestKeycodeNamed(t *testing.T) {
+	for u, name := range usageNames {
+		if name == "" {
+			continue
+		}
+		if s := Keycode(u).String(); s != name {
+			t.Errorf("%#02x String() = %q, want %q", u, s, name)
+		}
+	}
+}
+
+func TestModifierRoundTrip(t *testing.T) {
+	for m := 0; m <= 0xff; m++ {
+		s := Modifier(m).String()
+		got, err := ParseModifier(s)
+		if err != nil {
+			t.Errorf("%#02x: ParseModifier(%q): %s", m, s, err)
+			continue
+		}
+		if got != Modifier(m) {
+			t.Errorf("%#02x: ParseModifier(%q) = %#02x", m, s, uint8(got))
+		}
+	}
+}
+
+func TestParseKeycode(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Keycode
+		err  error
+	}{
+		{"tab", 0x2b, nil},
+		{" KP_1 ", 0x59, nil},
+		{"LCTRL", ModifierKey(LCtrl), nil},
+		{"lctrl|rshift", ModifierKey(LCtrl | RShift), nil},
+		{"LEFTCONTROL", 0xe0, nil},
+		{"0xa5", 0xa5, nil},
+		{"0x10000", 0, ErrInvalidKeycode},
+		{"", 0, ErrInvalidKeycode},
+		{"NOPE", 0, ErrInvalidKeycode},
+	}
+
+	for _, test := range tests {
+		kc, err := ParseKeycode(test.s)
+		if !errors.Is(err, test.err) || kc != test.want {
+			t.Errorf("ParseKeycode(%q) = %#04x, %v, want %#04x, %v", test.s, uint16(kc), err, uint16(test.want), test.err)
+		}
+	}
+}
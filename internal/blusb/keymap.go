@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Keymap text format markers
+const (
+	keymapComment = "#"
+	keymapLayer   = "layer"
+)
+
+// MarshalKeymap composes keymap formatted layers.  Each layer starts with a
+// "layer N" header followed by MatrixRows lines of MatrixCols key names
+// separated by whitespace.  Anything after a "#" is a comment.
+func (ls Layers) MarshalKeymap() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Blusb keymap\n")
+	for i, l := range ls {
+		fmt.Fprintf(buf, "\n%s %d\n", keymapLayer, i+1)
+
+		w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+		fmt.Fprint(w, keymapComment, " ")
+		for c := range l.Matrix[0] {
+			if c > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprintf(w, "C%d", c)
+		}
+		fmt.Fprintln(w)
+		for r := range l.Matrix {
+			for c := range l.Matrix[r] {
+				fmt.Fprintf(w, "%s\t", l.Matrix[r][c])
+			}
+			fmt.Fprintf(w, "%s R%d\n", keymapComment, r)
+		}
+		if err := w.Flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalKeymap parses keymap formatted layers as composed by
+// MarshalKeymap.  Blank lines and comments are ignored.
+func (ls *Layers) UnmarshalKeymap(text []byte) error {
+	var l Layer
+	r := -1
+	s := bufio.NewScanner(bytes.NewReader(text))
+	for line := 1; s.Scan(); line++ {
+		t := s.Text()
+		if i := strings.Index(t, keymapComment); i >= 0 {
+			t = t[:i]
+		}
+		f := strings.Fields(t)
+		if len(f) < 1 {
+			continue
+		}
+
+		if strings.EqualFold(f[0], keymapLayer) {
+			if r >= 0 {
+				return fmt.Errorf("line %d: %w: layer %d has %d rows", line, ErrInvalidKeymap, len(*ls)+1, r)
+			}
+			if len(f) != 2 || f[1] != strconv.Itoa(len(*ls)+1) {
+				return fmt.Errorf("line %d: %w: expected layer %d header", line, ErrInvalidKeymap, len(*ls)+1)
+			}
+			l, r = Layer{}, 0
+			continue
+		}
+
+		if r < 0 {
+			return fmt.Errorf("line %d: %w: keys before layer header", line, ErrInvalidKeymap)
+		}
+		if len(f) != MatrixCols {
+			return fmt.Errorf("line %d: %w: row has %d keys", line, ErrInvalidKeymap, len(f))
+		}
+		for c := range f {
+			k, err := ParseKeycode(f[c])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			l.Matrix[r][c] = k
+		}
+
+		if r++; r == MatrixRows {
+			*ls = append(*ls, l)
+			r = -1
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if r >= 0 {
+		return fmt.Errorf("%w: layer %d has %d rows", ErrInvalidKeymap, len(*ls)+1, r)
+	}
+
+	return nil
+}
+
+// IsKeymap indicates if text looks like keymap formatted layers rather than
+// CSV.
+func IsKeymap(text []byte) bool {
+	t := bytes.TrimSpace(text)
+	return bytes.HasPrefix(t, []byte(keymapComment)) ||
+		len(t) >= len(keymapLayer) && strings.EqualFold(string(t[:len(keymapLayer)]), keymapLayer)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testKeymapRow returns a keymap row with every key set to name.
+func testKeymapRow(name string) string {
+	return strings.TrimSpace(strings.Repeat(name+" ", MatrixCols))
+}
+
+// testKeymapLayer returns a keymap layer with every key set to name.
+func testKeymapLayer(n int, name string) string {
+	var b strings.Builder
+	b.WriteString("layer " + strconv.Itoa(n) + "\n")
+	for r := 0; r < MatrixRows; r++ {
+		b.WriteString(testKeymapRow(name) + "\n")
+	}
+
+	return b.String()
+}
+
+func TestKeymapRoundTrip(t *testing.T) {
+	for _, n := range []int{1, 2, 6} {
+		want := testLayers(n)
+		for i := range want {
+			want[i].Matrix[0][0] = ModifierKey(LCtrl | RAlt)
+		}
+
+		text, err := want.MarshalKeymap()
+		if err != nil {
+			t.Fatalf("%d layers: MarshalKeymap: %s", n, err)
+		}
+		if !IsKeymap(text) {
+			t.Errorf("%d layers: IsKeymap is false", n)
+		}
+		var got Layers
+		if err := got.UnmarshalKeymap(text); err != nil {
+			t.Fatalf("%d layers: UnmarshalKeymap: %s", n, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%d layers: got %d layers", n, len(got))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%d layers: layer %d differs", n, i+1)
+			}
+		}
+	}
+}
+
+func TestUnmarshalKeymap(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("# Comment before the first layer\n\n")
+	b.WriteString("  LAYER 1   # Header with a comment\n")
+	for r := 0; r < MatrixRows; r++ {
+		if r == 2 {
+			b.WriteString("\n   \n# Comment between rows\n")
+		}
+		b.WriteString("  " + strings.Repeat("a\t", MatrixCols) + "# R\n")
+	}
+	b.WriteString("\n#\n")
+	b.WriteString(testKeymapLayer(2, "lctrl|lshift"))
+
+	var ls Layers
+	if err := ls.UnmarshalKeymap([]byte(b.String())); err != nil {
+		t.Fatalf("UnmarshalKeymap: %s", err)
+	}
+	if len(ls) != 2 {
+		t.Fatalf("%d layers, want 2", len(ls))
+	}
+	for r := range ls[0].Matrix {
+		for c := range ls[0].Matrix[r] {
+			if k := ls[0].Matrix[r][c]; k != 0x04 {
+				t.Fatalf("layer 1 R%d C%d is %s, want A", r, c, k)
+			}
+			if k, want := ls[1].Matrix[r][c], ModifierKey(LCtrl|LShift); k != want {
+				t.Fatalf("layer 2 R%d C%d is %s, want %s", r, c, k, want)
+			}
+		}
+	}
+}
+
+func TestUnmarshalKeymapErrors(t *testing.T) {
+	layer1 := testKeymapLayer(1, "A")
+	short := strings.Join(strings.Split(layer1, "\n")[:MatrixRows], "\n") + "\n"
+
+	tests := []struct {
+		name string
+		text string
+		err  error
+	}{
+		{"keys before header", testKeymapRow("A") + "\n" + layer1, ErrInvalidKeymap},
+		{"header without number", "layer\n", ErrInvalidKeymap},
+		{"header out of order", testKeymapLayer(2, "A"), ErrInvalidKeymap},
+		{"repeated header", layer1 + testKeymapLayer(1, "A"), ErrInvalidKeymap},
+		{"short layer", short + testKeymapLayer(2, "A"), ErrInvalidKeymap},
+		{"short last layer", short, ErrInvalidKeymap},
+		{"short row", "layer 1\nA B C\n", ErrInvalidKeymap},
+		{"unknown key", "layer 1\n" + testKeymapRow("NOTAKEY") + "\n", ErrInvalidKeycode},
+	}
+
+	for _, test := range tests {
+		var ls Layers
+		if err := ls.UnmarshalKeymap([]byte(test.text)); !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestIsKeymap(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"# Blusb keymap\n", true},
+		{"\n  Layer 1\n", true},
+		{"0x29,0x3a\n", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if got := IsKeymap([]byte(test.text)); got != test.want {
+			t.Errorf("IsKeymap(%q) = %t, want %t", test.text, got, test.want)
+		}
+	}
+}
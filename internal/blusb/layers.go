@@ -7,25 +7,27 @@ package blusb
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
 )
 
+// Controller matrix dimensions
 const (
-	matrixRows = 8
-	matrixCols = 20
+	MatrixRows = 8
+	MatrixCols = 20
 )
 
 // Layer represents one layer.
 type Layer struct {
-	// Each layer has a matrixRows*matrixCols matrix, representing up to
+	// Each layer has a MatrixRows*MatrixCols matrix, representing up to
 	// 160 keys.
 	//
-	// Each key is 2-bytes with the higher byte representing any modifiers
-	// and the lower byte being a key code.
-	Matrix [matrixRows][matrixCols]uint16
+	// Each key is a 2-byte Keycode with the higher byte indicating how the
+	// lower byte is interpreted.
+	Matrix [MatrixRows][MatrixCols]Keycode
 }
 
 func (l Layer) String() string {
@@ -39,7 +41,7 @@ func (l Layer) String() string {
 	for r := range l.Matrix {
 		fmt.Fprintf(buf, "R%-1d  ", r)
 		for c := range l.Matrix[r] {
-			fmt.Fprintf(buf, "%04X  ", l.Matrix[r][c])
+			fmt.Fprintf(buf, "%04X  ", uint16(l.Matrix[r][c]))
 		}
 		buf.WriteByte('\n')
 	}
@@ -73,7 +75,7 @@ func (ls Layers) MarshalBinary() (data []byte, err error) {
 	//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	//   | 1 Key Code    |     Row 0, Col 2 Key Code     | ...           |
 	//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	data = make([]byte, 0, 1+len(ls)*matrixRows*matrixCols)
+	data = make([]byte, 0, 1+len(ls)*MatrixRows*MatrixCols*2)
 	data = append(data, byte(len(ls)))
 	for _, l := range ls {
 		for r := range l.Matrix {
@@ -93,10 +95,10 @@ func (ls *Layers) UnmarshalBinary(data []byte) error {
 	var r, c int
 	var numLayers = int(data[0])
 	for i := 1; i < len(data); i += 2 {
-		l.Matrix[r][c] = uint16(data[i+1])<<8 | uint16(data[i])
-		if c < matrixCols-1 {
+		l.Matrix[r][c] = Keycode(data[i+1])<<8 | Keycode(data[i])
+		if c < MatrixCols-1 {
 			c++
-		} else if r < matrixRows-1 {
+		} else if r < MatrixRows-1 {
 			r++
 			c = 0
 		} else {
@@ -122,7 +124,7 @@ func (ls Layers) MarshalText() ([]byte, error) {
 				if r > 0 || c > 0 {
 					buf.WriteString(", ")
 				}
-				fmt.Fprintf(buf, "%X", l.Matrix[r][c])
+				fmt.Fprintf(buf, "%X", uint16(l.Matrix[r][c]))
 			}
 		}
 		buf.WriteByte('\n')
@@ -145,10 +147,10 @@ func (ls *Layers) UnmarshalText(text []byte) error {
 			return err
 		}
 
-		l.Matrix[r][c] = uint16(u)
-		if c < matrixCols-1 {
+		l.Matrix[r][c] = Keycode(u)
+		if c < MatrixCols-1 {
 			c++
-		} else if r < matrixRows-1 {
+		} else if r < MatrixRows-1 {
 			r++
 			c = 0
 		} else {
@@ -270,6 +272,13 @@ func (c Controller) GetLayers() (ls Layers, err error) {
 
 // SetLayers sets the controller layers.
 func (c Controller) SetLayers(ls Layers) error {
+	return c.SetLayersContext(context.Background(), ls, nil)
+}
+
+// SetLayersContext sets the controller layers and calls progress after each
+// page is sent.  If the context is canceled it stops between pages and
+// returns the context error.
+func (c Controller) SetLayersContext(ctx context.Context, ls Layers, progress ProgressFunc) error {
 	data, err := ls.MarshalBinary()
 	if err != nil {
 		return err
@@ -278,6 +287,10 @@ func (c Controller) SetLayers(ls Layers) error {
 	// Create layer pages from data and write to controller.
 	p := layersPager{Buffer: bytes.NewBuffer(data)}
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		page := make([]byte, layersPageSize)
 		_, err := p.Read(page)
 		if err != nil {
@@ -290,7 +303,16 @@ func (c Controller) SetLayers(ls Layers) error {
 		if err := c.setControlReport(page); err != nil {
 			return err
 		}
+		progress.report(Progress{
+			Page:       int(page[2]),
+			Pages:      int(page[1]),
+			Bytes:      len(data) - p.Len(),
+			TotalBytes: len(data),
+		})
 	}
 
+	if c.verifying() {
+		return c.verifyLayers(ls)
+	}
 	return nil
 }
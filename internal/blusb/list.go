@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceInfo describes an attached controller.
+type DeviceInfo struct {
+	Bus     int    // USB bus number
+	Address int    // USB device address on the bus
+	Port    string // Port path, e.g. "1-2.3" for port 3 of a hub on port 2 of bus 1
+	Serial  string // Serial number, if the device has one
+	Mode    Mode   // Firmware or bootloader mode
+	Version string // Firmware version, empty in bootloader mode
+}
+
+func (i DeviceInfo) String() string {
+	return fmt.Sprintf("Bus: %d Address: %d Port: %s Serial: %s Mode: %s",
+		i.Bus, i.Address, i.Port, i.Serial, i.Mode)
+}
+
+// usbPort formats a port path the same way as Linux sysfs.
+func usbPort(bus int, path []int) string {
+	ports := make([]string, len(path))
+	for i := range path {
+		ports[i] = strconv.Itoa(path[i])
+	}
+
+	return fmt.Sprintf("%d-%s", bus, strings.Join(ports, "."))
+}
+
+// Selector selects a specific controller when more than one is attached.
+// Zero value fields match anything so the zero Selector matches every
+// controller.
+type Selector struct {
+	Bus     int    // USB bus number
+	Address int    // USB device address on the bus
+	Port    string // Port path
+	Serial  string // Serial number
+}
+
+// ParseSelector parses a selector.  It's either "bus:address" or a comma
+// separated list of bus=, addr=, port= and serial= fields, e.g.
+// "port=1-2.3" or "serial=ABC123".
+func ParseSelector(s string) (sel Selector, err error) {
+	if bus, addr, ok := strings.Cut(s, ":"); ok && !strings.Contains(s, "=") {
+		if sel.Bus, err = strconv.Atoi(bus); err != nil {
+			return Selector{}, ErrInvalidSelector
+		}
+		if sel.Address, err = strconv.Atoi(addr); err != nil {
+			return Selector{}, ErrInvalidSelector
+		}
+		return
+	}
+
+	for _, f := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(f), "=")
+		if !ok || v == "" {
+			return Selector{}, ErrInvalidSelector
+		}
+
+		switch k {
+		case "bus":
+			sel.Bus, err = strconv.Atoi(v)
+		case "addr", "address":
+			sel.Address, err = strconv.Atoi(v)
+		case "port":
+			sel.Port = v
+		case "serial":
+			sel.Serial = v
+		default:
+			err = ErrInvalidSelector
+		}
+		if err != nil {
+			return Selector{}, ErrInvalidSelector
+		}
+	}
+
+	return
+}
+
+// Match indicates if the device is selected.
+func (sel Selector) Match(i DeviceInfo) bool {
+	return (sel.Bus == 0 || sel.Bus == i.Bus) &&
+		(sel.Address == 0 || sel.Address == i.Address) &&
+		(sel.Port == "" || sel.Port == i.Port) &&
+		(sel.Serial == "" || sel.Serial == i.Serial)
+}
+
+func (sel Selector) String() string {
+	var f []string
+	if sel.Bus > 0 {
+		f = append(f, "bus="+strconv.Itoa(sel.Bus))
+	}
+	if sel.Address > 0 {
+		f = append(f, "addr="+strconv.Itoa(sel.Address))
+	}
+	if sel.Port != "" {
+		f = append(f, "port="+sel.Port)
+	}
+	if sel.Serial != "" {
+		f = append(f, "serial="+sel.Serial)
+	}
+
+	return strings.Join(f, ",")
+}
+
+// selector combines optional selectors into one.  The last non-zero field
+// wins.
+func selector(sels []Selector) (sel Selector) {
+	for _, s := range sels {
+		if s.Bus > 0 {
+			sel.Bus = s.Bus
+		}
+		if s.Address > 0 {
+			sel.Address = s.Address
+		}
+		if s.Port != "" {
+			sel.Port = s.Port
+		}
+		if s.Serial != "" {
+			sel.Serial = s.Serial
+		}
+	}
+
+	return
+}
+
+// List returns every attached controller in either mode using the default
+// backend.  Controllers running the firmware are briefly opened to read
+// the firmware version.
+func List() ([]DeviceInfo, error) {
+	b, err := defaultBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := b.List(VID, PID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range infos {
+		infos[i].Mode = FirmwareMode
+
+		c, err := Open(Selector{Bus: infos[i].Bus, Address: infos[i].Address})
+		if err != nil {
+			Debug.Printf("List open error: %s", err)
+			continue
+		}
+		if maj, min, err := c.GetVersion(); err == nil {
+			infos[i].Version = fmt.Sprintf("%d.%d", maj, min)
+		}
+		c.Close()
+	}
+
+	boots, err := b.List(BootVID, BootPID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range boots {
+		boots[i].Mode = BootMode
+	}
+
+	return append(infos, boots...), nil
+}
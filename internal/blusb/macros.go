@@ -20,9 +20,80 @@ const (
 
 // Macro represents one macro.
 type Macro struct {
-	Mods     uint8    // Internal modifier key codes
-	Reserved uint8    // Reserved for future use
-	Key      [6]uint8 // Up to 6 key codes
+	Mods     Modifier   // Modifier keys
+	Reserved uint8      // Reserved for future use
+	Key      [6]Keycode // Up to 6 key codes
+}
+
+// Reserved byte prefix in the macro text form
+const macroReserved = "RESERVED="
+
+// String returns the macro as its modifiers and keys joined by "+", e.g.
+// "LCTRL|LALT+DELETE", or "NONE" if it's empty.  Empty key slots ahead of the
+// last key are written as NONE so every key keeps its slot, e.g. "NONE+A",
+// and a non-zero reserved byte is added in hexadecimal, e.g. "A+RESERVED=01".
+func (m Macro) String() string {
+	var parts []string
+	if m.Mods != 0 {
+		parts = append(parts, m.Mods.String())
+	}
+	n := len(m.Key)
+	for n > 0 && m.Key[n-1] == 0 {
+		n--
+	}
+	for _, k := range m.Key[:n] {
+		parts = append(parts, k.String())
+	}
+	if m.Reserved != 0 {
+		parts = append(parts, fmt.Sprintf("%s%02X", macroReserved, m.Reserved))
+	}
+	if len(parts) == 0 {
+		return "NONE"
+	}
+
+	return strings.Join(parts, "+")
+}
+
+// ParseMacro parses a macro as returned by String.  Modifier names set
+// modifier bits and everything else is a key that takes the next slot,
+// including NONE.
+func ParseMacro(s string) (m Macro, err error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "NONE") {
+		return
+	}
+
+	var n int
+	for _, f := range strings.Split(s, "+") {
+		f = strings.TrimSpace(f)
+		if len(f) > len(macroReserved) && strings.EqualFold(f[:len(macroReserved)], macroReserved) {
+			u, err := strconv.ParseUint(f[len(macroReserved):], 16, 8)
+			if err != nil {
+				return m, fmt.Errorf("%w: %q isn't a reserved byte", ErrInvalidMacro, f)
+			}
+			m.Reserved = uint8(u)
+			continue
+		}
+		if mod, err := ParseModifier(f); err == nil && mod != 0 {
+			m.Mods |= mod
+			continue
+		}
+
+		k, err := ParseKeycode(f)
+		if err != nil {
+			return m, err
+		}
+		if k > 0xff {
+			return m, fmt.Errorf("%w: %q isn't a key", ErrInvalidMacro, f)
+		}
+		if n == len(m.Key) {
+			return m, fmt.Errorf("%w: more than %d keys", ErrInvalidMacro, len(m.Key))
+		}
+		m.Key[n] = k
+		n++
+	}
+
+	return
 }
 
 // Macros represents the full macro table.
@@ -33,9 +104,11 @@ func (ms Macros) MarshalBinary() (data []byte, err error) {
 	data = make([]byte, 1+len(ms)*macroSize)
 	data[0] = firmMacros
 	for i := range ms {
-		data[1+i*macroSize] = ms[i].Mods
+		data[1+i*macroSize] = byte(ms[i].Mods)
 		data[1+i*macroSize+1] = ms[i].Reserved
-		copy(data[1+i*macroSize+2:i*macroSize+8], ms[i].Key[:])
+		for k := range ms[i].Key {
+			data[1+i*macroSize+2+k] = ms[i].Key[k].Usage()
+		}
 	}
 
 	return
@@ -46,9 +119,11 @@ func (ms *Macros) UnmarshalBinary(data []byte) error {
 	// XXX Why isn't the ID included but the data size has
 	// space for it?
 	for i := range ms {
-		(*ms)[i].Mods = data[i*macroSize]
+		(*ms)[i].Mods = Modifier(data[i*macroSize])
 		(*ms)[i].Reserved = data[i*macroSize+1]
-		copy((*ms)[i].Key[:], data[i*macroSize+2:i*macroSize+8])
+		for k := range (*ms)[i].Key {
+			(*ms)[i].Key[k] = Keycode(data[i*macroSize+2+k])
+		}
 	}
 
 	return nil
@@ -60,9 +135,9 @@ func (ms *Macros) UnmarshalBinary(data []byte) error {
 func (ms Macros) MarshalText() ([]byte, error) {
 	buf := &bytes.Buffer{}
 	for _, m := range ms {
-		fmt.Fprintf(buf, "%X, %X", m.Mods, m.Reserved)
+		fmt.Fprintf(buf, "%X, %X", uint8(m.Mods), m.Reserved)
 		for _, k := range m.Key {
-			fmt.Fprintf(buf, ", %X", k)
+			fmt.Fprintf(buf, ", %X", k.Usage())
 		}
 		buf.WriteByte('\n')
 	}
@@ -89,11 +164,11 @@ func (ms *Macros) UnmarshalText(text []byte) error {
 
 			switch p {
 			case 0:
-				(*ms)[i].Mods = uint8(u)
+				(*ms)[i].Mods = Modifier(u)
 			case 1:
 				(*ms)[i].Reserved = uint8(u)
 			default:
-				(*ms)[i].Key[p-2] = uint8(u)
+				(*ms)[i].Key[p-2] = Keycode(u)
 			}
 		}
 	}
@@ -106,9 +181,9 @@ func (ms Macros) String() string {
 	buf.WriteString("     MODS  RSVD  KEY1  KEY2  KEY3  KEY4  KEY5  KEY6\n\n")
 
 	for i, m := range ms {
-		fmt.Fprintf(buf, "M%02d  %02X    %02X", i+1, m.Mods, m.Reserved)
+		fmt.Fprintf(buf, "M%02d  %02X    %02X", i+1, uint8(m.Mods), m.Reserved)
 		for _, k := range m.Key {
-			fmt.Fprintf(buf, "    %02X", k)
+			fmt.Fprintf(buf, "    %02X", k.Usage())
 		}
 		buf.WriteByte('\n')
 	}
@@ -135,5 +210,12 @@ func (c Controller) SetMacros(ms Macros) error {
 		return err
 	}
 
-	return c.setControlReport(data)
+	if err := c.setControlReport(data); err != nil {
+		return err
+	}
+
+	if c.verifying() {
+		return c.verifyMacros(ms)
+	}
+	return nil
 }
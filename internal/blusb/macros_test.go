@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMacroString(t *testing.T) {
+	tests := []struct {
+		m Macro
+		s string
+	}{
+		{Macro{}, "NONE"},
+		{Macro{Mods: LCtrl | LAlt, Key: [6]Keycode{0x4c}}, "LCTRL|LALT+DELETE"},
+		{Macro{Key: [6]Keycode{0x04, 0x05}}, "A+B"},
+		{Macro{Key: [6]Keycode{2: 0x04}}, "NONE+NONE+A"},
+		{Macro{Mods: LShift, Key: [6]Keycode{0x04, 0, 0x05}}, "LSHIFT+A+NONE+B"},
+		{Macro{Key: [6]Keycode{5: 0x04}}, "NONE+NONE+NONE+NONE+NONE+A"},
+		{Macro{Reserved: 0x5a}, "RESERVED=5A"},
+		{Macro{Mods: RGUI, Reserved: 1, Key: [6]Keycode{1: 0xe0}}, "RGUI+NONE+LEFTCONTROL+RESERVED=01"},
+	}
+
+	for _, test := range tests {
+		if s := test.m.String(); s != test.s {
+			t.Errorf("%+v String() = %q, want %q", test.m, s, test.s)
+		}
+		m, err := ParseMacro(test.s)
+		if err != nil {
+			t.Errorf("ParseMacro(%q): %s", test.s, err)
+			continue
+		}
+		if m != test.m {
+			t.Errorf("ParseMacro(%q) = %+v, want %+v", test.s, m, test.m)
+		}
+	}
+}
+
+func TestParseMacro(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Macro
+		err  error
+	}{
+		{" none ", Macro{}, nil},
+		{"LCTRL + a", Macro{Mods: LCtrl, Key: [6]Keycode{0x04}}, nil},
+		{"reserved=ff", Macro{Reserved: 0xff}, nil},
+		{"A+B+C+D+E+F+G", Macro{}, ErrInvalidMacro},
+		{"RESERVED=100", Macro{}, ErrInvalidMacro},
+		{"0x101", Macro{}, ErrInvalidMacro},
+		{"NOTAKEY", Macro{}, ErrInvalidKeycode},
+	}
+
+	for _, test := range tests {
+		m, err := ParseMacro(test.s)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseMacro(%q) error %v, want %v", test.s, err, test.err)
+			continue
+		}
+		if err == nil && m != test.want {
+			t.Errorf("ParseMacro(%q) = %+v, want %+v", test.s, m, test.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+// Progress represents how far along a paged write is.
+type Progress struct {
+	Page  int // Pages sent
+	Pages int // Total pages
+
+	Bytes      int // Bytes written
+	TotalBytes int // Total bytes
+}
+
+// Done indicates if all pages have been sent.
+func (p Progress) Done() bool { return p.Page >= p.Pages }
+
+// ProgressFunc is called after each page of a paged write is sent.
+type ProgressFunc func(Progress)
+
+func (f ProgressFunc) report(p Progress) {
+	if f != nil {
+		f(p)
+	}
+}
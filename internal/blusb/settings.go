@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"time"
+)
+
+// Brightness represents the Num Lock, Caps Lock, and Scroll Lock LED
+// brightness values for USB and Bluetooth modes.
+type Brightness struct {
+	USB       uint `json:"usb" yaml:"usb"`
+	Bluetooth uint `json:"bluetooth" yaml:"bluetooth"`
+}
+
+// Settings represents the configurable settings of a controller.  Zero value
+// fields are left unchanged when set.
+type Settings struct {
+	Layers     Layers
+	Macros     *Macros
+	Brightness *Brightness
+	Debounce   time.Duration
+}
+
+// IsZero indicates if there are no settings.
+func (s Settings) IsZero() bool {
+	return s.Layers == nil && s.Macros == nil && s.Brightness == nil && s.Debounce == 0
+}
+
+// setting is one non-zero setting and how to set it.
+type setting struct {
+	name string
+	set  func(context.Context, Controller, ProgressFunc) error
+}
+
+// settings returns the non-zero settings in the order they're set.  Layers
+// are last since they're paged and the progress function is called after
+// each page.
+func (s Settings) settings() (ss []setting) {
+	if s.Brightness != nil {
+		ss = append(ss, setting{"brightness", func(_ context.Context, c Controller, _ ProgressFunc) error {
+			return c.SetBrightness(s.Brightness.USB, s.Brightness.Bluetooth)
+		}})
+	}
+	if s.Debounce > 0 {
+		ss = append(ss, setting{"debounce", func(_ context.Context, c Controller, _ ProgressFunc) error {
+			return c.SetDebounce(s.Debounce)
+		}})
+	}
+	if s.Macros != nil {
+		ss = append(ss, setting{"macros", func(_ context.Context, c Controller, _ ProgressFunc) error {
+			return c.SetMacros(*s.Macros)
+		}})
+	}
+	if s.Layers != nil {
+		ss = append(ss, setting{"layers", func(ctx context.Context, c Controller, progress ProgressFunc) error {
+			return c.SetLayersContext(ctx, s.Layers, progress)
+		}})
+	}
+
+	return
+}
+
+// Set sets every non-zero setting.
+func (c Controller) Set(ctx context.Context, s Settings, progress ProgressFunc) error {
+	for _, st := range s.settings() {
+		if err := st.set(ctx, c, progress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
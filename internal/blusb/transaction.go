@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RollbackError is returned by Transaction when a set fails and the
+// settings that were already set are restored.
+type RollbackError struct {
+	Setting     string   // Setting that failed
+	Err         error    // Error setting it
+	RolledBack  []string // Settings that were restored
+	RollbackErr error    // Error restoring them, if any
+}
+
+func (e *RollbackError) Error() string {
+	msg := fmt.Sprintf("set %s: %s", e.Setting, e.Err)
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("%s; rollback failed: %s", msg, e.RollbackErr)
+	}
+
+	return fmt.Sprintf("%s; rolled back %s", msg, strings.Join(e.RolledBack, ", "))
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// snapshot returns the current values of the non-zero settings.
+func (c Controller) snapshot(s Settings) (snap Settings, err error) {
+	if s.Brightness != nil {
+		var b Brightness
+		b.USB, b.Bluetooth, err = c.GetBrightness()
+		if err != nil {
+			return
+		}
+		snap.Brightness = &b
+	}
+
+	if s.Debounce > 0 {
+		snap.Debounce, err = c.GetDebounce()
+		if err != nil {
+			return
+		}
+	}
+
+	if s.Macros != nil {
+		var ms Macros
+		ms, err = c.GetMacros()
+		if err != nil {
+			return
+		}
+		snap.Macros = &ms
+	}
+
+	if s.Layers != nil {
+		snap.Layers, err = c.GetLayers()
+	}
+
+	return
+}
+
+// Transaction sets every non-zero setting as a unit.  The current values are
+// snapshotted first and if any set fails, including by the context being
+// canceled, everything that was set up to and including the failed one is
+// restored and a *RollbackError is returned.
+func (c Controller) Transaction(ctx context.Context, s Settings, progress ProgressFunc) error {
+	snap, err := c.snapshot(s)
+	if err != nil {
+		return err
+	}
+
+	undo := map[string]setting{}
+	for _, u := range snap.settings() {
+		undo[u.name] = u
+	}
+
+	ss := s.settings()
+	for i, st := range ss {
+		err := st.set(ctx, c, progress)
+		if err == nil {
+			continue
+		}
+
+		// The failed setting may be partially set, e.g. some layer pages,
+		// so it's restored too.  This runs to completion even if the
+		// context was canceled.
+		rerr := &RollbackError{Setting: st.name, Err: err}
+		for _, st := range ss[:i+1] {
+			u, ok := undo[st.name]
+			if !ok {
+				// Nothing valid to restore, e.g. a zero debounce.
+				continue
+			}
+			if err := u.set(context.Background(), c, nil); err != nil {
+				rerr.RollbackErr = fmt.Errorf("%s: %w", u.name, err)
+				break
+			}
+			rerr.RolledBack = append(rerr.RolledBack, u.name)
+		}
+
+		return rerr
+	}
+
+	return nil
+}
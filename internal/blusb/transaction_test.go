@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testSettings returns settings that differ from a new emulator's.
+func testSettings() Settings {
+	var ms Macros
+	ms[0] = Macro{Mods: LCtrl, Key: [6]Keycode{0x04}}
+
+	return Settings{
+		Layers:     testLayers(2),
+		Macros:     &ms,
+		Brightness: &Brightness{USB: 1, Bluetooth: 2},
+		Debounce:   3 * time.Millisecond,
+	}
+}
+
+// emulatorSettings returns the current settings of an emulator.
+func emulatorSettings(t *testing.T, e *Emulator) Settings {
+	t.Helper()
+
+	s, err := New(e).snapshot(testSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestTransaction(t *testing.T) {
+	e := NewEmulator()
+	want := testSettings()
+	if err := New(e).Transaction(context.Background(), want, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := emulatorSettings(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("settings are %+v, want %+v", got, want)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	errIO := errors.New("i/o error")
+
+	tests := []struct {
+		name       string
+		id         byte
+		setting    string
+		rolledBack []string
+	}{
+		{"first", firmBrightness, "brightness", nil},
+		{"middle", firmMacros, "macros", []string{"brightness", "debounce"}},
+		{"last", firmLayers, "layers", []string{"brightness", "debounce", "macros"}},
+	}
+
+	for _, test := range tests {
+		e := NewEmulator()
+		want := emulatorSettings(t, e)
+
+		// The failed setting can't be restored either since every set
+		// of it fails.
+		c := New(faultyTransport{Emulator: e, id: test.id, err: errIO})
+		err := c.Transaction(context.Background(), testSettings(), nil)
+		var rerr *RollbackError
+		if !errors.As(err, &rerr) {
+			t.Errorf("%s: error %v isn't a *RollbackError", test.name, err)
+			continue
+		}
+		if !errors.Is(err, errIO) {
+			t.Errorf("%s: error %v doesn't wrap %v", test.name, err, errIO)
+		}
+		if rerr.Setting != test.setting {
+			t.Errorf("%s: failed setting is %s, want %s", test.name, rerr.Setting, test.setting)
+		}
+		if !reflect.DeepEqual(rerr.RolledBack, test.rolledBack) {
+			t.Errorf("%s: rolled back %v, want %v", test.name, rerr.RolledBack, test.rolledBack)
+		}
+		if !errors.Is(rerr.RollbackErr, errIO) {
+			t.Errorf("%s: rollback error %v, want %v", test.name, rerr.RollbackErr, errIO)
+		}
+		if got := emulatorSettings(t, e); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: settings are %+v, want %+v", test.name, got, want)
+		}
+	}
+}
+
+// Canceling partway through the layers rolls everything back, including the
+// layer pages that were already set.
+func TestTransactionCanceled(t *testing.T) {
+	e := NewEmulator()
+	want := emulatorSettings(t, e)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress := func(Progress) { cancel() }
+	err := New(e).Transaction(ctx, testSettings(), progress)
+
+	var rerr *RollbackError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("error %v isn't a *RollbackError", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error %v doesn't wrap %v", err, context.Canceled)
+	}
+	if rerr.RollbackErr != nil {
+		t.Errorf("rollback error %v", rerr.RollbackErr)
+	}
+	if wantRolledBack := []string{"brightness", "debounce", "macros", "layers"}; !reflect.DeepEqual(rerr.RolledBack, wantRolledBack) {
+		t.Errorf("rolled back %v, want %v", rerr.RolledBack, wantRolledBack)
+	}
+	if got := emulatorSettings(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("settings are %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+// Transport sends and receives feature reports to and from an opened
+// device.
+type Transport interface {
+	// GetFeature reads feature report id into b and returns the number of
+	// bytes read.
+	GetFeature(id byte, b []byte) (int, error)
+
+	// SetFeature sends b as a feature report.  The first byte is the
+	// report ID.
+	SetFeature(b []byte) error
+
+	// Close releases the device.
+	Close() error
+
+	// String describes the device.
+	String() string
+
+	// Info returns the device bus location and serial number.
+	Info() DeviceInfo
+}
+
+// Backend lists and opens transports to devices.
+type Backend interface {
+	// List returns every device matching vid and pid.
+	List(vid, pid ID) ([]DeviceInfo, error)
+
+	// Open opens the first device matching vid, pid, and the selector.  It
+	// returns ErrControllerNotFound if there isn't one.
+	Open(vid, pid ID, sel Selector) (Transport, error)
+}
+
+// Backends holds the available backends by name.  Which ones are available
+// depends on the platform and build tags.
+var Backends = map[string]Backend{}
+
+// Backend preference when DefaultBackend isn't set
+var backendPreference = []string{"usb", "hidraw"}
+
+// defaultBackend returns DefaultBackend or the preferred available backend
+// if it isn't set.
+func defaultBackend() (Backend, error) {
+	b := DefaultBackend
+	for _, name := range backendPreference {
+		if b != nil {
+			break
+		}
+		b = Backends[name]
+	}
+	if b == nil {
+		return nil, ErrNoBackend
+	}
+
+	return b, nil
+}
+
+// openBackend opens the selected device matching vid and pid using the
+// default backend.
+func openBackend(vid, pid ID, sel Selector) (Transport, error) {
+	b, err := defaultBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Open(vid, pid, sel)
+}
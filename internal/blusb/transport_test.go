@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// fakeTransport is a Transport that returns canned feature reports and
+// records what's set.
+type fakeTransport struct {
+	get    []byte // Report returned by GetFeature
+	getN   int    // Bytes GetFeature claims to have read, or -1 for len(b)
+	getErr error
+
+	sets [][]byte
+}
+
+func (t *fakeTransport) GetFeature(id byte, b []byte) (int, error) {
+	copy(b, t.get)
+	if t.getN < 0 {
+		return len(b), t.getErr
+	}
+	return t.getN, t.getErr
+}
+
+func (t *fakeTransport) SetFeature(b []byte) error {
+	t.sets = append(t.sets, append([]byte{}, b...))
+	return nil
+}
+
+func (t *fakeTransport) Close() error     { return nil }
+func (t *fakeTransport) String() string   { return "fake" }
+func (t *fakeTransport) Info() DeviceInfo { return DeviceInfo{} }
+
+func TestGetControlReport(t *testing.T) {
+	errIO := errors.New("i/o error")
+
+	tests := []struct {
+		name string
+		getN int
+		err  error
+		want error
+	}{
+		{"full", -1, nil, nil},
+		{"short", 7, nil, io.EOF},
+		{"empty", 0, nil, io.EOF},
+		{"error", 0, errIO, errIO},
+	}
+
+	for _, test := range tests {
+		c := New(&fakeTransport{get: []byte{128, 64}, getN: test.getN, getErr: test.err})
+		usb, bt, err := c.GetBrightness()
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: GetBrightness error %v, want %v", test.name, err, test.want)
+			continue
+		}
+		if err == nil && (usb != 128 || bt != 64) {
+			t.Errorf("%s: GetBrightness() = %d, %d, want 128, 64", test.name, usb, bt)
+		}
+	}
+}
+
+func TestSkipSets(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(Controller) error
+	}{
+		{"brightness", func(c Controller) error { return c.SetBrightness(1, 2) }},
+		{"debounce", func(c Controller) error { return c.SetDebounce(5 * time.Millisecond) }},
+		{"macros", func(c Controller) error { return c.SetMacros(Macros{}) }},
+		{"layers", func(c Controller) error { return c.SetLayers(Layers{Layer{}}) }},
+		{"enter boot", func(c Controller) error { return c.EnterBoot() }},
+	}
+
+	for _, test := range tests {
+		for _, skip := range []bool{false, true} {
+			ft := &fakeTransport{getN: -1}
+			c := New(ft)
+			c.SkipSets = skip
+			// Verify is ignored when sets are skipped since there's
+			// nothing to read back.
+			c.Verify = skip
+
+			if err := test.set(c); err != nil {
+				t.Errorf("%s: SkipSets=%t: %s", test.name, skip, err)
+			}
+			if sent := len(ft.sets) > 0; sent == skip {
+				t.Errorf("%s: SkipSets=%t sent %d reports", test.name, skip, len(ft.sets))
+			}
+		}
+	}
+}
+
+func TestLayersPagerRead(t *testing.T) {
+	data, _ := testLayers(2).MarshalBinary()
+	p := layersPager{Buffer: bytes.NewBuffer(data)}
+
+	var got []byte
+	for page := 1; ; page++ {
+		b := make([]byte, layersPageSize)
+		_, err := p.Read(b)
+		if err == io.EOF {
+			if page != 4 {
+				t.Errorf("EOF after %d pages, want 3", page-1)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("page %d: %s", page, err)
+		}
+		if b[0] != firmLayers || b[1] != 3 || int(b[2]) != page {
+			t.Errorf("page %d header is % x, want %02x 03 %02x", page, b[:3], firmLayers, page)
+		}
+		got = append(got, b[layersPageHeadSize:]...)
+	}
+
+	// The last page is padded with 0xff's.
+	if !bytes.Equal(got[:len(data)], data) {
+		t.Error("page data doesn't match the layers")
+	}
+	if pad := got[len(data):]; !bytes.Equal(pad, bytes.Repeat([]byte{0xff}, len(pad))) {
+		t.Errorf("last page padding is % x", pad)
+	}
+}
+
+func TestLayersPagerWrite(t *testing.T) {
+	page := func(id, total, current byte) []byte {
+		b := make([]byte, layersPageSize)
+		b[0], b[1], b[2] = id, total, current
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		pages [][]byte
+		errs  []error
+	}{
+		{"firmware ID", [][]byte{page(firmLayers, 2, 1), page(firmLayers, 2, 2)}, []error{nil, io.EOF}},
+		{"zero ID", [][]byte{page(0, 2, 1), page(0, 2, 2)}, []error{nil, io.EOF}},
+		{"bad ID", [][]byte{page(0x55, 2, 1)}, []error{io.ErrNoProgress}},
+		{"out of order", [][]byte{page(0, 3, 1), page(0, 3, 3)}, []error{nil, io.ErrNoProgress}},
+		{"short", [][]byte{{0, 1}}, []error{io.ErrShortWrite}},
+	}
+
+	for _, test := range tests {
+		p := layersPager{Buffer: &bytes.Buffer{}}
+		for i, b := range test.pages {
+			if _, err := p.Write(b); !errors.Is(err, test.errs[i]) {
+				t.Errorf("%s: page %d error %v, want %v", test.name, i+1, err, test.errs[i])
+			}
+		}
+	}
+}
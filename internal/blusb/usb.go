@@ -0,0 +1,165 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !nogousb
+
+package blusb
+
+import (
+	"fmt"
+
+	"github.com/google/gousb"
+)
+
+// Bitmap request types
+const (
+	reqGetReport uint8 = 0x01
+	reqSetReport uint8 = 0x09
+)
+
+// Specific requests
+const (
+	repIn uint16 = (iota + 0x0001) << 8
+	repOut
+	repFeature
+)
+
+// USB is the libusb backend which sends feature reports as HID class
+// control requests.  It requires cgo and can be excluded with the nogousb
+// build tag.
+var USB Backend = usbBackend{}
+
+func init() {
+	Backends["usb"] = USB
+}
+
+type usbBackend struct{}
+
+// usbTransport holds an opened USB device context.
+type usbTransport struct {
+	// USB device handling context
+	ctx *gousb.Context
+
+	// Opened USB device
+	dev *gousb.Device
+
+	// Release claimed interface and config
+	done func()
+}
+
+func usbInfo(desc *gousb.DeviceDesc) DeviceInfo {
+	return DeviceInfo{
+		Bus:     desc.Bus,
+		Address: desc.Address,
+		Port:    usbPort(desc.Bus, desc.Path),
+	}
+}
+
+// openDevices opens every device matching vid, pid, and the selector.
+func openDevices(ctx *gousb.Context, vid, pid ID, sel Selector) ([]*gousb.Device, error) {
+	devs, err := ctx.OpenDevices(func(desc *gousb.DeviceDesc) bool {
+		// The serial number can't be read until the device is opened.
+		s := sel
+		s.Serial = ""
+		return desc.Vendor == gousb.ID(vid) && desc.Product == gousb.ID(pid) &&
+			s.Match(usbInfo(desc))
+	})
+	if sel.Serial == "" {
+		return devs, err
+	}
+
+	var matched []*gousb.Device
+	for _, dev := range devs {
+		if serial, _ := dev.SerialNumber(); serial == sel.Serial {
+			matched = append(matched, dev)
+		} else {
+			dev.Close()
+		}
+	}
+
+	return matched, err
+}
+
+// List returns every device matching vid and pid.  Each one is briefly
+// opened to read the serial number.
+func (usbBackend) List(vid, pid ID) ([]DeviceInfo, error) {
+	ctx := gousb.NewContext()
+	defer ctx.Close()
+
+	devs, err := openDevices(ctx, vid, pid, Selector{})
+	infos := make([]DeviceInfo, 0, len(devs))
+	for _, dev := range devs {
+		i := usbInfo(dev.Desc)
+		i.Serial, _ = dev.SerialNumber()
+		infos = append(infos, i)
+		dev.Close()
+	}
+
+	return infos, err
+}
+
+// Open opens the device and claims the default interface.
+func (usbBackend) Open(vid, pid ID, sel Selector) (Transport, error) {
+	ctx := gousb.NewContext()
+
+	devs, err := openDevices(ctx, vid, pid, sel)
+	if len(devs) < 1 {
+		ctx.Close()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrControllerNotFound
+	}
+	dev := devs[0]
+	for _, d := range devs[1:] {
+		d.Close()
+	}
+
+	// We only send control requests and don't care about the interface but
+	// Linux doesn't like it when we don't claim it.
+	_, done, err := dev.DefaultInterface()
+	if err != nil {
+		dev.Close()
+		ctx.Close()
+		return nil, err
+	}
+
+	return usbTransport{
+		ctx:  ctx,
+		dev:  dev,
+		done: done,
+	}, nil
+}
+
+func (t usbTransport) GetFeature(id byte, b []byte) (int, error) {
+	return t.dev.Control(gousb.ControlInterface|gousb.ControlIn|gousb.ControlClass,
+		reqGetReport, repFeature|uint16(id), 0, b)
+}
+
+func (t usbTransport) SetFeature(b []byte) error {
+	_, err := t.dev.Control(gousb.ControlInterface|gousb.ControlOut|gousb.ControlClass,
+		reqSetReport, repFeature|uint16(b[0]), 0, b)
+	return err
+}
+
+func (t usbTransport) Close() error {
+	t.done()
+	t.dev.Close()
+	return t.ctx.Close()
+}
+
+func (t usbTransport) String() string {
+	m, _ := t.dev.Manufacturer()
+	p, _ := t.dev.Product()
+
+	return fmt.Sprintf("Bus: %d Address: %d Manufacturer: %s (%s) Product: %s (%s)",
+		t.dev.Desc.Bus, t.dev.Desc.Address, m, t.dev.Desc.Vendor, p, t.dev.Desc.Product)
+}
+
+func (t usbTransport) Info() DeviceInfo {
+	i := usbInfo(t.dev.Desc)
+	i.Serial, _ = t.dev.SerialNumber()
+
+	return i
+}
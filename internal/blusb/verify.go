@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VerifyError is returned in verify mode when a setting read back from the
+// controller doesn't match what was set.
+type VerifyError struct {
+	Setting   string // "layers", "macros", "brightness", or "debounce"
+	Want, Got string // Values for brightness and debounce
+	Diff      Diff   // Differing positions for layers and macros, from set to read back
+}
+
+func (e *VerifyError) Error() string {
+	if e.Diff.IsZero() {
+		return fmt.Sprintf("%s %s: set %s, read %s", e.Setting, ErrVerify, e.Want, e.Got)
+	}
+
+	diff := strings.TrimSuffix(e.Diff.String(), "\n")
+	return fmt.Sprintf("%s %s (set -> read):\n%s", e.Setting, ErrVerify, diff)
+}
+
+// Is allows errors.Is(err, ErrVerify).
+func (e *VerifyError) Is(target error) bool {
+	return target == ErrVerify
+}
+
+// verifying indicates if set operations should be read back.  There's
+// nothing to verify if sets are being skipped.
+func (c Controller) verifying() bool {
+	return c.Verify && !c.SkipSets
+}
+
+func (c Controller) verifyLayers(ls Layers) error {
+	got, err := c.GetLayers()
+	if err != nil {
+		return err
+	}
+	if d := DiffLayers(ls, got); !d.IsZero() {
+		return &VerifyError{Setting: "layers", Diff: d}
+	}
+
+	return nil
+}
+
+func (c Controller) verifyMacros(ms Macros) error {
+	got, err := c.GetMacros()
+	if err != nil {
+		return err
+	}
+	if d := DiffMacros(ms, got); !d.IsZero() {
+		return &VerifyError{Setting: "macros", Diff: d}
+	}
+
+	return nil
+}
+
+func (c Controller) verifyBrightness(usb, bt uint) error {
+	gotUSB, gotBT, err := c.GetBrightness()
+	if err != nil {
+		return err
+	}
+	if gotUSB != usb || gotBT != bt {
+		return &VerifyError{
+			Setting: "brightness",
+			Want:    fmt.Sprintf("%d,%d", usb, bt),
+			Got:     fmt.Sprintf("%d,%d", gotUSB, gotBT),
+		}
+	}
+
+	return nil
+}
+
+func (c Controller) verifyDebounce(dur time.Duration) error {
+	got, err := c.GetDebounce()
+	if err != nil {
+		return err
+	}
+	// The controller only stores whole milliseconds.
+	if want := dur.Truncate(time.Millisecond); got != want {
+		return &VerifyError{Setting: "debounce", Want: want.String(), Got: got.String()}
+	}
+
+	return nil
+}
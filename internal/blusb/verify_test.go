@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	ls := Layers{Layer{}}
+	ls[0].Matrix[3][12] = 0x29
+	var ms Macros
+	ms[0] = Macro{Mods: LCtrl, Key: [6]Keycode{0x04}}
+
+	tests := []struct {
+		name string
+		id   byte
+		set  func(Controller) error
+		msg  string
+	}{
+		{"layers", firmLayers, func(c Controller) error { return c.SetLayers(ls) }, "Layer 1 R3 C12: ESC -> NONE"},
+		{"macros", firmMacros, func(c Controller) error { return c.SetMacros(ms) }, "Macro M01: LCTRL+A -> NONE"},
+		{"brightness", firmBrightness, func(c Controller) error { return c.SetBrightness(1, 2) }, "set 1,2, read 255,255"},
+		{"debounce", firmDebounce, func(c Controller) error { return c.SetDebounce(3 * time.Millisecond) }, "set 3ms, read 5ms"},
+	}
+
+	for _, test := range tests {
+		// Sets that are stored verify.
+		c := New(NewEmulator())
+		c.Verify = true
+		if err := test.set(c); err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+
+		c = New(faultyTransport{Emulator: NewEmulator(), id: test.id})
+		c.Verify = true
+		err := test.set(c)
+		if !errors.Is(err, ErrVerify) {
+			t.Errorf("%s: error %v, want %v", test.name, err, ErrVerify)
+			continue
+		}
+		var ve *VerifyError
+		if !errors.As(err, &ve) {
+			t.Errorf("%s: error %T isn't a *VerifyError", test.name, err)
+			continue
+		}
+		if ve.Setting != test.name {
+			t.Errorf("%s: setting is %q", test.name, ve.Setting)
+		}
+		if !strings.Contains(err.Error(), test.msg) {
+			t.Errorf("%s: error %q doesn't contain %q", test.name, err, test.msg)
+		}
+
+		// Without verify the mismatch goes unnoticed.
+		c.Verify = false
+		if err := test.set(c); err != nil {
+			t.Errorf("%s: without verify: %s", test.name, err)
+		}
+	}
+}
+
+// The controller only stores whole milliseconds so a fractional debounce
+// still verifies.
+func TestVerifyDebounceTruncated(t *testing.T) {
+	c := New(NewEmulator())
+	c.Verify = true
+	if err := c.SetDebounce(7*time.Millisecond + 500*time.Microsecond); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package blusb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Mode represents what the controller is running.
+type Mode int
+
+// Controller modes
+const (
+	FirmwareMode Mode = iota // Running the firmware
+	BootMode                 // Running the bootloader
+)
+
+func (m Mode) String() string {
+	switch m {
+	case FirmwareMode:
+		return "firmware"
+	case BootMode:
+		return "bootloader"
+	}
+
+	return "unknown"
+}
+
+// Handle is an opened controller in either firmware or bootloader mode.  The
+// concrete type is a Controller or a Bootloader respectively.
+type Handle interface {
+	Mode() Mode
+	Info() DeviceInfo
+	Close()
+	String() string
+}
+
+// How often to look for the controller while waiting
+var waitPoll = 250 * time.Millisecond
+
+// open opens the first selected controller found in any of the modes.
+func open(sel Selector, modes ...Mode) (Handle, error) {
+	err := ErrControllerNotFound
+	for _, m := range modes {
+		var h Handle
+		var herr error
+		switch m {
+		case FirmwareMode:
+			h, herr = Open(sel)
+		case BootMode:
+			h, herr = OpenBootloader(sel)
+		}
+		if herr == nil {
+			return h, nil
+		}
+		if !errors.Is(herr, ErrControllerNotFound) {
+			err = herr
+		}
+	}
+
+	return nil, err
+}
+
+// Probe reports which mode the selected controller is currently in without
+// keeping it open.
+func Probe(sel ...Selector) (Mode, error) {
+	h, err := open(selector(sel), FirmwareMode, BootMode)
+	if err != nil {
+		return 0, err
+	}
+	defer h.Close()
+
+	return h.Mode(), nil
+}
+
+// Wait waits for the selected controller to enumerate in any of the modes,
+// or either mode if none are specified, and returns a handle for it.  This
+// is useful after a mode change when the controller drops off the bus and
+// comes back with a different identity.  If it times out the error is
+// ErrWaitTimeout with the last error opening the controller, other than it
+// not being found, appended.
+//
+// The device address changes when it re-enumerates so select by port path
+// or serial number to follow a specific controller.
+func Wait(ctx context.Context, sel Selector, modes ...Mode) (Handle, error) {
+	if len(modes) < 1 {
+		modes = []Mode{FirmwareMode, BootMode}
+	}
+
+	// Errors other than not found, like permission denied, can be transient
+	// while the controller re-enumerates so the last one is reported if it
+	// never opens.
+	var lastErr error
+	t := time.NewTicker(waitPoll)
+	defer t.Stop()
+	for {
+		h, err := open(sel, modes...)
+		if err == nil {
+			return h, nil
+		}
+		Debug.Printf("Waiting for %v: %s", modes, err)
+		if !errors.Is(err, ErrControllerNotFound) {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if lastErr != nil {
+					return nil, fmt.Errorf("%w: %s", ErrWaitTimeout, lastErr)
+				}
+				return nil, ErrWaitTimeout
+			}
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitController waits for the selected controller to enumerate in firmware
+// mode.
+func WaitController(ctx context.Context, sel ...Selector) (Controller, error) {
+	h, err := Wait(ctx, selector(sel), FirmwareMode)
+	if err != nil {
+		return Controller{}, err
+	}
+
+	return h.(Controller), nil
+}
+
+// WaitBootloader waits for the selected controller to enumerate in
+// bootloader mode.
+func WaitBootloader(ctx context.Context, sel ...Selector) (Bootloader, error) {
+	h, err := Wait(ctx, selector(sel), BootMode)
+	if err != nil {
+		return Bootloader{}, err
+	}
+
+	return h.(Bootloader), nil
+}
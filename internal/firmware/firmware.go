@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package firmware implements loading and validation of flash images for the
+// Blusb Universal BT-USB Model M Controller.
+package firmware
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Controller flash geometry (ATmega32A with a 4KB boot section)
+const (
+	PageSize  = 0x80   // Flash page size in bytes
+	FlashSize = 0x8000 // Total flash size in bytes
+	BootStart = 0x7000 // Start of the bootloader section
+)
+
+// Errors
+var (
+	ErrEmpty       = errors.New("firmware image is empty")
+	ErrOverflow    = errors.New("firmware image overflows the application area")
+	ErrBootOverlap = errors.New("firmware image overlaps the bootloader section")
+	ErrChecksum    = errors.New("intel hex record checksum mismatch")
+	ErrRecord      = errors.New("malformed intel hex record")
+	ErrRecordType  = errors.New("unsupported intel hex record type")
+	ErrNoEOF       = errors.New("intel hex file is missing end of file record")
+)
+
+// Image represents a flash image for the application area starting at
+// address 0.  It's always a whole number of pages with unused bytes set to
+// 0xff, which is the erased state of the flash.
+type Image []byte
+
+// Pages returns the number of pages in the image.
+func (img Image) Pages() int {
+	return len(img) / PageSize
+}
+
+// Page returns the address and data of page i.
+func (img Image) Page(i int) (addr int, data []byte) {
+	addr = i * PageSize
+	return addr, img[addr : addr+PageSize]
+}
+
+// newImage builds a page aligned image from a sparse set of addressed
+// bytes and validates it against the flash geometry.
+func newImage(mem map[int]byte) (Image, error) {
+	if len(mem) < 1 {
+		return nil, ErrEmpty
+	}
+
+	end := 0
+	for addr := range mem {
+		if err := checkAddr(addr); err != nil {
+			return nil, err
+		}
+		if addr >= end {
+			end = addr + 1
+		}
+	}
+
+	img := Image(bytes.Repeat([]byte{0xff}, pageAlign(end)))
+	for addr, b := range mem {
+		img[addr] = b
+	}
+
+	return img, nil
+}
+
+func checkAddr(addr int) error {
+	switch {
+	case addr >= FlashSize:
+		return ErrOverflow
+	case addr >= BootStart:
+		return ErrBootOverlap
+	}
+
+	return nil
+}
+
+func pageAlign(n int) int {
+	return (n + PageSize - 1) / PageSize * PageSize
+}
+
+// ParseBin parses a raw binary image that starts at address 0.
+func ParseBin(data []byte) (Image, error) {
+	if len(data) < 1 {
+		return nil, ErrEmpty
+	}
+	if err := checkAddr(len(data) - 1); err != nil {
+		return nil, err
+	}
+
+	img := Image(bytes.Repeat([]byte{0xff}, pageAlign(len(data))))
+	copy(img, data)
+
+	return img, nil
+}
+
+// Load reads and parses the firmware image in filename.  Files with a .hex
+// or .ihx extension, or that look like Intel HEX, are parsed as such and
+// everything else is treated as a raw binary.
+func Load(filename string) (Image, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".hex", ".ihx":
+		return ParseHex(data)
+	case ".bin":
+		return ParseBin(data)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte{':'}) {
+		return ParseHex(data)
+	}
+	return ParseBin(data)
+}
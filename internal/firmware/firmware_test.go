@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package firmware
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseBin(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		err  error
+		want int // Image size
+	}{
+		{"empty", 0, ErrEmpty, 0},
+		{"one byte", 1, nil, PageSize},
+		{"one page", PageSize, nil, PageSize},
+		{"page and a byte", PageSize + 1, nil, 2 * PageSize},
+		{"application area", BootStart, nil, BootStart},
+		{"bootloader overlap", BootStart + 1, ErrBootOverlap, 0},
+		{"whole flash", FlashSize, ErrBootOverlap, 0},
+		{"past end of flash", FlashSize + 1, ErrOverflow, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := bytes.Repeat([]byte{0x5a}, test.size)
+			img, err := ParseBin(data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(img) != test.want {
+				t.Fatalf("image is %d bytes, want %d", len(img), test.want)
+			}
+			if !bytes.Equal(img[:len(data)], data) {
+				t.Error("image doesn't start with the data")
+			}
+			if pad := img[len(data):]; !bytes.Equal(pad, bytes.Repeat([]byte{0xff}, len(pad))) {
+				t.Errorf("padding is % x, want erased", []byte(pad))
+			}
+		})
+	}
+}
+
+func TestImagePages(t *testing.T) {
+	img, err := ParseBin(make([]byte, 3*PageSize-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if img.Pages() != 3 {
+		t.Fatalf("%d pages, want 3", img.Pages())
+	}
+	for i := 0; i < img.Pages(); i++ {
+		addr, data := img.Page(i)
+		if addr != i*PageSize || len(data) != PageSize {
+			t.Errorf("page %d is at %#x with %d bytes", i, addr, len(data))
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	hex := hexFile(record(recData, 0, 0x3a), eof)
+
+	tests := []struct {
+		name string
+		data []byte
+		want byte // First byte of the image
+	}{
+		{"fw.hex", hex, 0x3a},
+		{"fw.ihx", hex, 0x3a},
+		{"fw.bin", hex, ':'}, // Binary by extension even if it looks like hex
+		{"fw", hex, 0x3a},
+		{"fw.img", []byte{0x0c, 0x94}, 0x0c},
+	}
+
+	for _, test := range tests {
+		filename := filepath.Join(dir, test.name)
+		if err := os.WriteFile(filename, test.data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := Load(filename)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if img[0] != test.want {
+			t.Errorf("%s: first byte is %#x, want %#x", test.name, img[0], test.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package firmware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Intel HEX record types
+const (
+	recData         byte = iota // Data
+	recEOF                      // End of file
+	recExtSegAddr               // Extended segment address
+	recStartSegAddr             // Start segment address
+	recExtLinAddr               // Extended linear address
+	recStartLinAddr             // Start linear address
+)
+
+// ParseHex parses an Intel HEX formatted image.  Extended segment and
+// extended linear address records are supported and every record checksum
+// is verified.
+func ParseHex(text []byte) (Image, error) {
+	mem := map[int]byte{}
+	var base int
+
+	s := bufio.NewScanner(bytes.NewReader(text))
+	for line := 1; s.Scan(); line++ {
+		rec := bytes.TrimSpace(s.Bytes())
+		if len(rec) < 1 {
+			continue
+		}
+
+		//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+		//   | : | Byte Count |    Address    | Type | Data ... | Checksum   |
+		//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+		if rec[0] != ':' {
+			return nil, fmt.Errorf("line %d: %w", line, ErrRecord)
+		}
+		b := make([]byte, hex.DecodedLen(len(rec)-1))
+		if _, err := hex.Decode(b, rec[1:]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, ErrRecord)
+		}
+		if len(b) < 5 || len(b) != 5+int(b[0]) {
+			return nil, fmt.Errorf("line %d: %w", line, ErrRecord)
+		}
+
+		var sum byte
+		for _, v := range b {
+			sum += v
+		}
+		if sum != 0 {
+			return nil, fmt.Errorf("line %d: %w", line, ErrChecksum)
+		}
+
+		addr := int(b[1])<<8 | int(b[2])
+		data := b[4 : len(b)-1]
+		switch b[3] {
+		case recData:
+			for i, v := range data {
+				if err := checkAddr(base + addr + i); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				mem[base+addr+i] = v
+			}
+		case recEOF:
+			return newImage(mem)
+		case recExtSegAddr:
+			if len(data) != 2 {
+				return nil, fmt.Errorf("line %d: %w", line, ErrRecord)
+			}
+			base = (int(data[0])<<8 | int(data[1])) << 4
+		case recExtLinAddr:
+			if len(data) != 2 {
+				return nil, fmt.Errorf("line %d: %w", line, ErrRecord)
+			}
+			base = (int(data[0])<<8 | int(data[1])) << 16
+		case recStartSegAddr, recStartLinAddr:
+			// Execution start addresses don't apply to the AVR.
+		default:
+			return nil, fmt.Errorf("line %d: %w", line, ErrRecordType)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, ErrNoEOF
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package firmware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// record returns an Intel HEX record with a valid checksum.
+func record(typ byte, addr int, data ...byte) string {
+	b := append([]byte{byte(len(data)), byte(addr >> 8), byte(addr), typ}, data...)
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+
+	return fmt.Sprintf(":%X%02X", b, -sum)
+}
+
+func hexFile(records ...string) []byte {
+	return []byte(strings.Join(records, "\n") + "\n")
+}
+
+var eof = record(recEOF, 0)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		name string
+		text []byte
+		err  error
+		want map[int]byte // Non-erased bytes
+		size int
+	}{
+		{
+			name: "data",
+			text: hexFile(record(recData, 0x0000, 0x0c, 0x94), eof),
+			want: map[int]byte{0: 0x0c, 1: 0x94},
+			size: PageSize,
+		},
+		{
+			name: "lower case and blank lines",
+			text: []byte("\n" + strings.ToLower(record(recData, 0x0010, 0xab)) + "\n\n" + eof + "\n"),
+			want: map[int]byte{0x10: 0xab},
+			size: PageSize,
+		},
+		{
+			name: "page padding",
+			text: hexFile(record(recData, PageSize, 1), eof),
+			want: map[int]byte{PageSize: 1},
+			size: 2 * PageSize,
+		},
+		{
+			name: "last byte before bootloader",
+			text: hexFile(record(recData, BootStart-1, 1), eof),
+			want: map[int]byte{BootStart - 1: 1},
+			size: BootStart,
+		},
+		{
+			name: "extended segment address",
+			text: hexFile(record(recExtSegAddr, 0, 0x01, 0x00), record(recData, 0x0002, 7), eof),
+			want: map[int]byte{0x1002: 7},
+			size: 0x1000 + PageSize,
+		},
+		{
+			name: "extended linear address zero",
+			text: hexFile(record(recExtLinAddr, 0, 0x00, 0x00), record(recData, 0x0100, 9), eof),
+			want: map[int]byte{0x100: 9},
+			size: 0x100 + PageSize,
+		},
+		{
+			name: "start addresses ignored",
+			text: hexFile(record(recStartSegAddr, 0, 0, 0, 0, 0), record(recStartLinAddr, 0, 0, 0, 0, 0),
+				record(recData, 0, 1), eof),
+			want: map[int]byte{0: 1},
+			size: PageSize,
+		},
+		{
+			name: "records after EOF ignored",
+			text: hexFile(record(recData, 0, 1), eof, record(recData, 0x7fff, 1)),
+			want: map[int]byte{0: 1},
+			size: PageSize,
+		},
+		{
+			name: "checksum mismatch",
+			text: hexFile(":020000000C9463", eof),
+			err:  ErrChecksum,
+		},
+		{
+			name: "missing EOF",
+			text: hexFile(record(recData, 0, 1)),
+			err:  ErrNoEOF,
+		},
+		{
+			name: "empty",
+			text: hexFile(eof),
+			err:  ErrEmpty,
+		},
+		{
+			name: "bootloader overlap",
+			text: hexFile(record(recData, BootStart-1, 1, 2), eof),
+			err:  ErrBootOverlap,
+		},
+		{
+			name: "bootloader overlap with segment address",
+			text: hexFile(record(recExtSegAddr, 0, 0x07, 0x00), record(recData, 0, 1), eof),
+			err:  ErrBootOverlap,
+		},
+		{
+			name: "past end of flash",
+			text: hexFile(record(recData, FlashSize, 1), eof),
+			err:  ErrOverflow,
+		},
+		{
+			name: "past end of flash with linear address",
+			text: hexFile(record(recExtLinAddr, 0, 0x00, 0x01), record(recData, 0, 1), eof),
+			err:  ErrOverflow,
+		},
+		{
+			name: "no start code",
+			text: hexFile("020000000C9463", eof),
+			err:  ErrRecord,
+		},
+		{
+			name: "not hex",
+			text: hexFile(":0200000ZZZ", eof),
+			err:  ErrRecord,
+		},
+		{
+			name: "wrong byte count",
+			text: hexFile(":0300000001FC", eof),
+			err:  ErrRecord,
+		},
+		{
+			name: "short extended address",
+			text: hexFile(record(recExtLinAddr, 0, 0x01), eof),
+			err:  ErrRecord,
+		},
+		{
+			name: "unsupported record type",
+			text: hexFile(record(0x06, 0, 1), eof),
+			err:  ErrRecordType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := ParseHex(test.text)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(img) != test.size {
+				t.Errorf("image is %d bytes, want %d", len(img), test.size)
+			}
+			want := bytes.Repeat([]byte{0xff}, test.size)
+			for addr, b := range test.want {
+				want[addr] = b
+			}
+			if !bytes.Equal(img, want) {
+				t.Errorf("image is\n% x\nwant\n% x", []byte(img), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package layout describes the physical layouts of Model M keyboard variants
+// and maps each physical key to its Blusb controller matrix position.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// Errors
+var (
+	ErrUnknownLayout  = errors.New("unknown layout")
+	ErrInvalidQMK     = errors.New("invalid QMK keymap")
+	ErrNoQMKLayout    = errors.New("no QMK layout")
+	ErrInvalidVIA     = errors.New("invalid VIA definition")
+	ErrNoQMKKeycode   = errors.New("keycode has no QMK equivalent")
+	ErrNoBlusbKeycode = errors.New("QMK keycode has no Blusb equivalent")
+)
+
+// Key represents one physical key.
+type Key struct {
+	Label string  // Legend printed on the keycap
+	X, Y  float64 // Top left corner in key units (1u is one alphanumeric key)
+	W, H  float64 // Width and height in key units
+
+	// Controller matrix position the key is wired to
+	Pos blusb.MatrixPos
+}
+
+// Layout represents a physical keyboard layout.
+type Layout struct {
+	Name        string // Short name, e.g. "ansi"
+	Description string // Human readable name
+	Keys        []Key  // Physical keys ordered from top left to bottom right
+}
+
+func (l Layout) String() string {
+	return l.Description
+}
+
+// Key returns the physical key wired to the matrix position.
+func (l Layout) Key(pos blusb.MatrixPos) (Key, bool) {
+	for _, k := range l.Keys {
+		if k.Pos == pos {
+			return k, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// KeyName returns the label of the physical key wired to the matrix
+// position, or a row and column description like "R3 C12" if there isn't
+// one.
+func (l Layout) KeyName(pos blusb.MatrixPos) string {
+	if k, ok := l.Key(pos); ok {
+		return k.Label
+	}
+
+	return fmt.Sprintf("R%d C%d", pos.Row, pos.Col)
+}
+
+// Size returns the width and height of the layout in key units.
+func (l Layout) Size() (w, h float64) {
+	for _, k := range l.Keys {
+		if k.X+k.W > w {
+			w = k.X + k.W
+		}
+		if k.Y+k.H > h {
+			h = k.Y + k.H
+		}
+	}
+
+	return
+}
+
+// layouts holds the built-in layouts by name.
+var layouts = map[string]Layout{}
+
+func init() {
+	for _, l := range []Layout{ansi, iso, m4gISO, iso122_1, iso122_2, iso122_3, iso122_4} {
+		layouts[l.Name] = l
+	}
+}
+
+// Names returns the sorted names of the built-in layouts.
+func Names() []string {
+	names := make([]string, 0, len(layouts))
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Get returns the built-in layout by name.
+func Get(name string) (Layout, error) {
+	l, ok := layouts[name]
+	if !ok {
+		return Layout{}, fmt.Errorf("%w: %s", ErrUnknownLayout, name)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,817 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import "github.com/ebarkie/goblusb/internal/blusb"
+
+// The matrix positions come from the default layers in the layers
+// directory.  The 122-key variants have ten left block keys whose physical
+// legends vary so they're labeled with what the default layer sends and
+// placed in matrix order.  Keys the default layer leaves unassigned aren't
+// included.
+//
+// The ANSI default layer also assigns the two ISO-only positions, the "\ |"
+// key left of Z and the "#" key left of Enter, so it works with an ISO
+// board.  A 101-key board doesn't have them so they're omitted on purpose.
+
+// IBM Model M 101-key ANSI
+var ansi = Layout{
+	Name:        "ansi",
+	Description: "IBM Model M 101-key ANSI",
+	Keys: []Key{
+		{Label: "Esc", X: 0, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "F1", X: 2, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "F2", X: 3, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "F3", X: 4, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: "F4", X: 5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 11}},
+		{Label: "F5", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 9}},
+		{Label: "F6", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F7", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "F8", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "F9", X: 11, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "F10", X: 12, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "F11", X: 13, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "F12", X: 14, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 3}},
+		{Label: "Print Screen", X: 15.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "Scroll Lock", X: 16.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "Pause", X: 17.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "`", X: 0, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 13}},
+		{Label: "1", X: 1, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 13}},
+		{Label: "2", X: 2, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 12}},
+		{Label: "3", X: 3, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "4", X: 4, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "5", X: 5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "6", X: 6, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "7", X: 7, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "8", X: 8, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "9", X: 9, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "0", X: 10, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "-", X: 11, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "=", X: 12, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "Backspace", X: 13, Y: 1.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "Insert", X: 15.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 3}},
+		{Label: "Home", X: 16.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 1}},
+		{Label: "Page Up", X: 17.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 2}},
+		{Label: "Num Lock", X: 18.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "KP /", X: 19.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 3}},
+		{Label: "KP *", X: 20.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "KP -", X: 21.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 2}},
+		{Label: "Tab", X: 0, Y: 2.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 13}},
+		{Label: "Q", X: 1.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 13}},
+		{Label: "W", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 12}},
+		{Label: "E", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "R", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "T", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "Y", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "U", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "I", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "O", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "P", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "[", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "]", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "\\", X: 13.5, Y: 2.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 9}},
+		{Label: "Delete", X: 15.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "End", X: 16.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 1}},
+		{Label: "Page Down", X: 17.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 2}},
+		{Label: "KP 7", X: 18.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "KP 8", X: 19.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 3}},
+		{Label: "KP 9", X: 20.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 2}},
+		{Label: "KP +", X: 21.5, Y: 2.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 4, Col: 1}},
+		{Label: "Caps Lock", X: 0, Y: 3.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 12}},
+		{Label: "A", X: 1.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 13}},
+		{Label: "S", X: 2.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "D", X: 3.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: "F", X: 4.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "G", X: 5.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 10}},
+		{Label: "H", X: 6.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "J", X: 7.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "K", X: 8.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "L", X: 9.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: ";", X: 10.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "'", X: 11.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "Enter", X: 12.75, Y: 3.5, W: 2.25, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "KP 4", X: 18.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "KP 5", X: 19.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 3}},
+		{Label: "KP 6", X: 20.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "Left Shift", X: 0, Y: 4.5, W: 2.25, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 17}},
+		{Label: "Z", X: 2.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 13}},
+		{Label: "X", X: 3.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 12}},
+		{Label: "C", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: "V", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "B", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 10}},
+		{Label: "N", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "M", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: ",", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: ".", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "/", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "Right Shift", X: 12.25, Y: 4.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "Up", X: 16.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "KP 1", X: 18.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "KP 2", X: 19.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 3}},
+		{Label: "KP 3", X: 20.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 2}},
+		{Label: "KP Enter", X: 21.5, Y: 4.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 5, Col: 1}},
+		{Label: "Left Ctrl", X: 0, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "Left Alt", X: 2.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "Space", X: 4, Y: 5.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 9}},
+		{Label: "Right Alt", X: 11, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 0}},
+		{Label: "Right Ctrl", X: 13.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "Left", X: 15.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 1}},
+		{Label: "Down", X: 16.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 4}},
+		{Label: "Right", X: 17.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 3}},
+		{Label: "KP 0", X: 18.5, Y: 5.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 3}},
+		{Label: "KP .", X: 20.5, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 2}},
+	},
+}
+
+// IBM Model M 102-key ISO
+var iso = Layout{
+	Name:        "iso",
+	Description: "IBM Model M 102-key ISO",
+	Keys: []Key{
+		{Label: "Esc", X: 0, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "F1", X: 2, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "F2", X: 3, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "F3", X: 4, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: "F4", X: 5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 11}},
+		{Label: "F5", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 9}},
+		{Label: "F6", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F7", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "F8", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "F9", X: 11, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "F10", X: 12, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "F11", X: 13, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "F12", X: 14, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 3}},
+		{Label: "Print Screen", X: 15.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "Scroll Lock", X: 16.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "Pause", X: 17.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "`", X: 0, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 13}},
+		{Label: "1", X: 1, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 13}},
+		{Label: "2", X: 2, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 12}},
+		{Label: "3", X: 3, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "4", X: 4, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "5", X: 5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "6", X: 6, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "7", X: 7, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "8", X: 8, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "9", X: 9, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "0", X: 10, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "-", X: 11, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "=", X: 12, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "Backspace", X: 13, Y: 1.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "Insert", X: 15.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 3}},
+		{Label: "Home", X: 16.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 1}},
+		{Label: "Page Up", X: 17.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 2}},
+		{Label: "Num Lock", X: 18.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "KP /", X: 19.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 3}},
+		{Label: "KP *", X: 20.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "KP -", X: 21.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 2}},
+		{Label: "Tab", X: 0, Y: 2.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 13}},
+		{Label: "Q", X: 1.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 13}},
+		{Label: "W", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 12}},
+		{Label: "E", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "R", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "T", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "Y", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "U", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "I", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "O", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "P", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "[", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "]", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "Enter", X: 13.75, Y: 2.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "Delete", X: 15.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "End", X: 16.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 1}},
+		{Label: "Page Down", X: 17.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 2}},
+		{Label: "KP 7", X: 18.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "KP 8", X: 19.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 3}},
+		{Label: "KP 9", X: 20.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 2}},
+		{Label: "KP +", X: 21.5, Y: 2.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 4, Col: 1}},
+		{Label: "Caps Lock", X: 0, Y: 3.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 12}},
+		{Label: "A", X: 1.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 13}},
+		{Label: "S", X: 2.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "D", X: 3.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: "F", X: 4.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "G", X: 5.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 10}},
+		{Label: "H", X: 6.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "J", X: 7.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "K", X: 8.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "L", X: 9.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: ";", X: 10.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "'", X: 11.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "#", X: 12.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: "KP 4", X: 18.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "KP 5", X: 19.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 3}},
+		{Label: "KP 6", X: 20.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "Left Shift", X: 0, Y: 4.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 17}},
+		{Label: "\\ |", X: 1.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 12}},
+		{Label: "Z", X: 2.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 13}},
+		{Label: "X", X: 3.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 12}},
+		{Label: "C", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: "V", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "B", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 10}},
+		{Label: "N", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "M", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: ",", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: ".", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "/", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "Right Shift", X: 12.25, Y: 4.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "Up", X: 16.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "KP 1", X: 18.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "KP 2", X: 19.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 3}},
+		{Label: "KP 3", X: 20.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 2}},
+		{Label: "KP Enter", X: 21.5, Y: 4.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 5, Col: 1}},
+		{Label: "Left Ctrl", X: 0, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "Left Alt", X: 2.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "Space", X: 4, Y: 5.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 9}},
+		{Label: "Right Alt", X: 11, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 0}},
+		{Label: "Right Ctrl", X: 13.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "Left", X: 15.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 1}},
+		{Label: "Down", X: 16.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 4}},
+		{Label: "Right", X: 17.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 3}},
+		{Label: "KP 0", X: 18.5, Y: 5.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 3}},
+		{Label: "KP .", X: 20.5, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 2}},
+	},
+}
+
+// IBM Model M 102-key ISO with M4G wiring
+var m4gISO = Layout{
+	Name:        "m4g-iso",
+	Description: "IBM Model M 102-key ISO with M4G wiring",
+	Keys: []Key{
+		{Label: "Esc", X: 0, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 16}},
+		{Label: "F1", X: 2, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 0}},
+		{Label: "F2", X: 3, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 1}},
+		{Label: "F3", X: 4, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 1}},
+		{Label: "F4", X: 5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "F5", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 9}},
+		{Label: "F6", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 12}},
+		{Label: "F7", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: "F8", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "F9", X: 11, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "F10", X: 12, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "F11", X: 13, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "F12", X: 14, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "Print Screen", X: 15.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 3}},
+		{Label: "Scroll Lock", X: 16.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 3}},
+		{Label: "Pause", X: 17.25, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "`", X: 0, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "1", X: 1, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 16}},
+		{Label: "2", X: 2, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "3", X: 3, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 1}},
+		{Label: "4", X: 4, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 17}},
+		{Label: "5", X: 5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 17}},
+		{Label: "6", X: 6, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 13}},
+		{Label: "7", X: 7, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 13}},
+		{Label: "8", X: 8, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 12}},
+		{Label: "9", X: 9, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "0", X: 10, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "-", X: 11, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "=", X: 12, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "Backspace", X: 13, Y: 1.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "Insert", X: 15.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "Home", X: 16.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "Page Up", X: 17.25, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "Num Lock", X: 18.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: "KP /", X: 19.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "KP *", X: 20.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: "KP -", X: 21.5, Y: 1.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "Tab", X: 0, Y: 2.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 16}},
+		{Label: "Q", X: 1.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 16}},
+		{Label: "W", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "E", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 1}},
+		{Label: "R", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 17}},
+		{Label: "T", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 17}},
+		{Label: "Y", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 13}},
+		{Label: "U", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 13}},
+		{Label: "I", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 12}},
+		{Label: "O", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "P", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "[", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "]", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 12}},
+		{Label: "Enter", X: 13.75, Y: 2.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "Delete", X: 15.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "End", X: 16.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "Page Down", X: 17.25, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "KP 7", X: 18.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "KP 8", X: 19.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "KP 9", X: 20.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "KP +", X: 21.5, Y: 2.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "Caps Lock", X: 0, Y: 3.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 0}},
+		{Label: "A", X: 1.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 16}},
+		{Label: "S", X: 2.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 0}},
+		{Label: "D", X: 3.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 1}},
+		{Label: "F", X: 4.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 17}},
+		{Label: "G", X: 5.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 17}},
+		{Label: "H", X: 6.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "J", X: 7.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 13}},
+		{Label: "K", X: 8.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "L", X: 9.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: ";", X: 10.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "'", X: 11.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 10}},
+		{Label: "#", X: 12.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "KP 4", X: 18.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "KP 5", X: 19.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "KP 6", X: 20.5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "Left Shift", X: 0, Y: 4.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "\\ |", X: 1.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "Z", X: 2.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "X", X: 3.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 0}},
+		{Label: "C", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "V", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "B", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 17}},
+		{Label: "N", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 13}},
+		{Label: "M", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 13}},
+		{Label: ",", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 12}},
+		{Label: ".", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: "/", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 10}},
+		{Label: "Right Shift", X: 12.25, Y: 4.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: "Up", X: 16.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 4}},
+		{Label: "KP 1", X: 18.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "KP 2", X: 19.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: "KP 3", X: 20.5, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "KP Enter", X: 21.5, Y: 4.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "Left Ctrl", X: 0, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 2}},
+		{Label: "Left Alt", X: 2.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 3}},
+		{Label: "Space", X: 4, Y: 5.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 9}},
+		{Label: "Right Alt", X: 11, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 3}},
+		{Label: "Right Ctrl", X: 13.5, Y: 5.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "Left", X: 15.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 4}},
+		{Label: "Down", X: 16.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 7}},
+		{Label: "Right", X: 17.25, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 6}},
+		{Label: "KP 0", X: 18.5, Y: 5.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 6}},
+		{Label: "KP .", X: 20.5, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+	},
+}
+
+// IBM Model M 122-key ISO with default 1 wiring
+var iso122_1 = Layout{
+	Name:        "122-iso-1",
+	Description: "IBM Model M 122-key ISO with default 1 wiring",
+	Keys: []Key{
+		{Label: "F13", X: 2.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F14", X: 3.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "F15", X: 4.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "F16", X: 5.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "F17", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "F18", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "F19", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "F20", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "F21", X: 10.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "F22", X: 11.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: "F23", X: 12.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 7}},
+		{Label: "F24", X: 13.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "F1", X: 2.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "F2", X: 3.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 9}},
+		{Label: "F3", X: 4.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "F4", X: 5.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "F5", X: 6.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "F6", X: 7.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "F7", X: 8.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "F8", X: 9.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 9}},
+		{Label: "F9", X: 10.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 9}},
+		{Label: "F10", X: 11.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: "F11", X: 12.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "F12", X: 13.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 9}},
+		{Label: "Pause", X: 0, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 19}},
+		{Label: "Esc", X: 1, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "`", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 2}},
+		{Label: "1", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 2}},
+		{Label: "2", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 3}},
+		{Label: "3", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "4", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "5", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "6", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "7", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "8", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "9", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "0", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "-", X: 13.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 12}},
+		{Label: "=", X: 14.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "Backspace", X: 15.5, Y: 2.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 13}},
+		{Label: "Insert", X: 17.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 17}},
+		{Label: "Home", X: 18.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 16}},
+		{Label: "Page Up", X: 19.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 17}},
+		{Label: "Num Lock", X: 21, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 13}},
+		{Label: "KP /", X: 22, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "KP *", X: 23, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 14}},
+		{Label: "KP -", X: 24, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 18}},
+		{Label: "Tab", X: 0, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "Print Screen", X: 1, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 19}},
+		{Label: "Tab", X: 2.5, Y: 3.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "Q", X: 4, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 2}},
+		{Label: "W", X: 5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 3}},
+		{Label: "E", X: 6, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "R", X: 7, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "T", X: 8, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "Y", X: 9, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: "U", X: 10, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "I", X: 11, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "O", X: 12, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "P", X: 13, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 12}},
+		{Label: "[", X: 14, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "]", X: 15, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "Enter", X: 16.25, Y: 3.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 6, Col: 13}},
+		{Label: "Delete", X: 17.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 17}},
+		{Label: "End", X: 18.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 17}},
+		{Label: "Page Down", X: 19.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 17}},
+		{Label: "KP 7", X: 21, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 13}},
+		{Label: "KP 8", X: 22, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 16}},
+		{Label: "KP 9", X: 23, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 14}},
+		{Label: "KP +", X: 24, Y: 3.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 1, Col: 18}},
+		{Label: "Caps Lock", X: 2.5, Y: 4.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 0}},
+		{Label: "A", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "S", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 3}},
+		{Label: "D", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "F", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "G", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "H", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 6}},
+		{Label: "J", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "K", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "L", X: 12.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: ";", X: 13.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 12}},
+		{Label: "'", X: 14.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 12}},
+		{Label: "#", X: 15.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 12}},
+		{Label: "KP 4", X: 21, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 13}},
+		{Label: "KP 5", X: 22, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 16}},
+		{Label: "KP 6", X: 23, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 14}},
+		{Label: "Left Shift", X: 2.5, Y: 5.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 1}},
+		{Label: "\\ |", X: 3.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 2}},
+		{Label: "Z", X: 4.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "X", X: 5.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 3}},
+		{Label: "C", X: 6.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "V", X: 7.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: "B", X: 8.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "N", X: 9.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 6}},
+		{Label: "M", X: 10.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: ",", X: 11.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: ".", X: 12.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: "/", X: 13.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 12}},
+		{Label: "Right Shift", X: 14.75, Y: 5.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "Up", X: 18.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 17}},
+		{Label: "KP 1", X: 21, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 19}},
+		{Label: "KP 2", X: 22, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "KP 3", X: 23, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 14}},
+		{Label: "KP Enter", X: 24, Y: 5.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 7, Col: 15}},
+		{Label: "Left Ctrl", X: 2.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 18}},
+		{Label: "Left Alt", X: 5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 18}},
+		{Label: "Space", X: 6.5, Y: 6.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 19}},
+		{Label: "Right Alt", X: 13.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "Right Ctrl", X: 16, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 0}},
+		{Label: "Left", X: 17.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 18}},
+		{Label: "Down", X: 18.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "Right", X: 19.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 15}},
+		{Label: "KP 0", X: 21, Y: 6.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 16}},
+		{Label: "KP .", X: 23, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 14}},
+	},
+}
+
+// IBM Model M 122-key ISO with default 2 wiring
+var iso122_2 = Layout{
+	Name:        "122-iso-2",
+	Description: "IBM Model M 122-key ISO with default 2 wiring",
+	Keys: []Key{
+		{Label: "F13", X: 2.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F14", X: 3.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "F15", X: 4.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "F16", X: 5.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "F17", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "F18", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "F19", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "F20", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "F21", X: 10.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: "F22", X: 11.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: "F23", X: 12.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 7}},
+		{Label: "F24", X: 13.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 6}},
+		{Label: "F1", X: 2.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 6}},
+		{Label: "F2", X: 3.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "F3", X: 4.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "F4", X: 5.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "F5", X: 6.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "F6", X: 7.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "F7", X: 8.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "F8", X: 9.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "F9", X: 10.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "F10", X: 11.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "F11", X: 12.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: "F12", X: 13.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "Print Screen", X: 0, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 19}},
+		{Label: "Pause", X: 1, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 19}},
+		{Label: "`", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "1", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 0}},
+		{Label: "2", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 1}},
+		{Label: "3", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 2}},
+		{Label: "4", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "5", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "6", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "7", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "8", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "9", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "0", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "-", X: 13.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "=", X: 14.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "Backspace", X: 15.5, Y: 2.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "Insert", X: 17.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 14}},
+		{Label: "Home", X: 18.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 14}},
+		{Label: "Page Up", X: 19.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 14}},
+		{Label: "Num Lock", X: 21, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "KP /", X: 22, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "KP *", X: 23, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 15}},
+		{Label: "KP -", X: 24, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 14}},
+		{Label: "Esc", X: 0, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 15}},
+		{Label: "Tab", X: 2.5, Y: 3.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 0}},
+		{Label: "Q", X: 4, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "W", X: 5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 1}},
+		{Label: "E", X: 6, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 2}},
+		{Label: "R", X: 7, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "T", X: 8, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "Y", X: 9, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "U", X: 10, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "I", X: 11, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "O", X: 12, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 9}},
+		{Label: "P", X: 13, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "[", X: 14, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "]", X: 15, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: "Enter", X: 16.25, Y: 3.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "Delete", X: 17.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 15}},
+		{Label: "End", X: 18.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 16}},
+		{Label: "Page Down", X: 19.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 15}},
+		{Label: "KP 7", X: 21, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 16}},
+		{Label: "KP 8", X: 22, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 17}},
+		{Label: "KP 9", X: 23, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 15}},
+		{Label: "KP +", X: 24, Y: 3.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 5, Col: 14}},
+		{Label: "Caps Lock", X: 2.5, Y: 4.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 1}},
+		{Label: "A", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 0}},
+		{Label: "S", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 1}},
+		{Label: "D", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "F", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "G", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 4}},
+		{Label: "H", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "J", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "K", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: "L", X: 12.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: ";", X: 13.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "'", X: 14.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 10}},
+		{Label: "#", X: 15.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "KP 4", X: 21, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 16}},
+		{Label: "KP 5", X: 22, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 17}},
+		{Label: "KP 6", X: 23, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 15}},
+		{Label: "Left Shift", X: 2.5, Y: 5.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 3}},
+		{Label: "\\ |", X: 3.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "Z", X: 4.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 0}},
+		{Label: "X", X: 5.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "C", X: 6.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "V", X: 7.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "B", X: 8.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 4}},
+		{Label: "N", X: 9.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "M", X: 10.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: ",", X: 11.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: ".", X: 12.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "/", X: 13.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 10}},
+		{Label: "Right Shift", X: 14.75, Y: 5.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 3}},
+		{Label: "Up", X: 18.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 13}},
+		{Label: "KP 1", X: 21, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 16}},
+		{Label: "KP 2", X: 22, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 17}},
+		{Label: "KP 3", X: 23, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 15}},
+		{Label: "KP Enter", X: 24, Y: 5.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 1, Col: 14}},
+		{Label: "Left Ctrl", X: 2.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 18}},
+		{Label: "Left Alt", X: 5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "Space", X: 6.5, Y: 6.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 19}},
+		{Label: "Right Alt", X: 13.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 13}},
+		{Label: "Right Ctrl", X: 16, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 18}},
+		{Label: "Left", X: 17.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 14}},
+		{Label: "Down", X: 18.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 16}},
+		{Label: "Right", X: 19.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 17}},
+		{Label: "KP 0", X: 21, Y: 6.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 17}},
+		{Label: "KP .", X: 23, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 15}},
+	},
+}
+
+// IBM Model M 122-key ISO with default 3 wiring
+var iso122_3 = Layout{
+	Name:        "122-iso-3",
+	Description: "IBM Model M 122-key ISO with default 3 wiring",
+	Keys: []Key{
+		{Label: "F13", X: 2.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 7}},
+		{Label: "F14", X: 3.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: "F15", X: 4.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: "F16", X: 5.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "F17", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "F18", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "F19", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "F20", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "F21", X: 10.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "F22", X: 11.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "F23", X: 12.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F24", X: 13.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "F1", X: 2.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "F2", X: 3.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 9}},
+		{Label: "F3", X: 4.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: "F4", X: 5.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "F5", X: 6.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 9}},
+		{Label: "F6", X: 7.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 9}},
+		{Label: "F7", X: 8.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "F8", X: 9.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "F9", X: 10.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 9}},
+		{Label: "F10", X: 11.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "F11", X: 12.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "F12", X: 13.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 9}},
+		{Label: "Print Screen", X: 0, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 19}},
+		{Label: "Esc", X: 1, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 19}},
+		{Label: "`", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 2}},
+		{Label: "1", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 2}},
+		{Label: "2", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 3}},
+		{Label: "3", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "4", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "5", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "6", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "7", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: "8", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "9", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: "0", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "-", X: 13.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 12}},
+		{Label: "=", X: 14.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "Backspace", X: 15.5, Y: 2.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 13}},
+		{Label: "Insert", X: 17.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 17}},
+		{Label: "Home", X: 18.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 16}},
+		{Label: "Page Up", X: 19.75, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 17}},
+		{Label: "Num Lock", X: 21, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 13}},
+		{Label: "KP /", X: 22, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 16}},
+		{Label: "KP *", X: 23, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 14}},
+		{Label: "KP -", X: 24, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 18}},
+		{Label: "Tab", X: 2.5, Y: 3.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "Q", X: 4, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 2}},
+		{Label: "W", X: 5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 3}},
+		{Label: "E", X: 6, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "R", X: 7, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "T", X: 8, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "Y", X: 9, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "U", X: 10, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "I", X: 11, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "O", X: 12, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "P", X: 13, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 12}},
+		{Label: "[", X: 14, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 12}},
+		{Label: "]", X: 15, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "Enter", X: 16.25, Y: 3.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 1, Col: 13}},
+		{Label: "Delete", X: 17.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "End", X: 18.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 17}},
+		{Label: "Page Down", X: 19.75, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 17}},
+		{Label: "KP 7", X: 21, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 13}},
+		{Label: "KP 8", X: 22, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 16}},
+		{Label: "KP 9", X: 23, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 14}},
+		{Label: "Caps Lock", X: 2.5, Y: 4.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 0}},
+		{Label: "A", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "S", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 3}},
+		{Label: "D", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "F", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: "G", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "H", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 6}},
+		{Label: "J", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "K", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "L", X: 12.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: ";", X: 13.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 12}},
+		{Label: "'", X: 14.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 12}},
+		{Label: "#", X: 15.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 12}},
+		{Label: "KP 4", X: 21, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 13}},
+		{Label: "KP 5", X: 22, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "KP 6", X: 23, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 14}},
+		{Label: "Left Shift", X: 2.5, Y: 5.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "\\ |", X: 3.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 2}},
+		{Label: "Z", X: 4.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "X", X: 5.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 3}},
+		{Label: "C", X: 6.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "V", X: 7.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: "B", X: 8.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "N", X: 9.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 6}},
+		{Label: "M", X: 10.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: ",", X: 11.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: ".", X: 12.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: "/", X: 13.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 12}},
+		{Label: "Right Shift", X: 14.75, Y: 5.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 1}},
+		{Label: "Up", X: 18.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 17}},
+		{Label: "KP 1", X: 21, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 19}},
+		{Label: "KP 2", X: 22, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 16}},
+		{Label: "KP 3", X: 23, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 14}},
+		{Label: "KP Enter", X: 24, Y: 5.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 0, Col: 15}},
+		{Label: "Left Ctrl", X: 2.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 18}},
+		{Label: "Left Alt", X: 5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 18}},
+		{Label: "Space", X: 6.5, Y: 6.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 19}},
+		{Label: "Right Alt", X: 13.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 1}},
+		{Label: "Right Ctrl", X: 16, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "Left", X: 17.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 18}},
+		{Label: "Down", X: 18.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 13}},
+		{Label: "Right", X: 19.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 15}},
+		{Label: "KP 0", X: 21, Y: 6.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 16}},
+		{Label: "KP .", X: 23, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 14}},
+	},
+}
+
+// IBM Model M 122-key ISO with default 4 wiring
+var iso122_4 = Layout{
+	Name:        "122-iso-4",
+	Description: "IBM Model M 122-key ISO with default 4 wiring",
+	Keys: []Key{
+		{Label: "F13", X: 2.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 7}},
+		{Label: "F14", X: 3.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 7}},
+		{Label: "F15", X: 4.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 6}},
+		{Label: "F16", X: 5.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 7}},
+		{Label: "F17", X: 6.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 7}},
+		{Label: "F18", X: 7.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 6}},
+		{Label: "F19", X: 8.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 7}},
+		{Label: "F20", X: 9.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 7}},
+		{Label: "F21", X: 10.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 6}},
+		{Label: "F22", X: 11.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 7}},
+		{Label: "F23", X: 12.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 7}},
+		{Label: "F24", X: 13.5, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 6}},
+		{Label: "F1", X: 2.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 6}},
+		{Label: "F2", X: 3.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 8}},
+		{Label: "F3", X: 4.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 8}},
+		{Label: "F4", X: 5.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 6}},
+		{Label: "F5", X: 6.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 8}},
+		{Label: "F6", X: 7.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 8}},
+		{Label: "F7", X: 8.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 6}},
+		{Label: "F8", X: 9.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 8}},
+		{Label: "F9", X: 10.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 8}},
+		{Label: "F10", X: 11.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 6}},
+		{Label: "F11", X: 12.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 8}},
+		{Label: "F12", X: 13.5, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 8}},
+		{Label: "Print Screen", X: 0, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 19}},
+		{Label: "`", X: 2.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 0}},
+		{Label: "1", X: 3.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 0}},
+		{Label: "2", X: 4.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 1}},
+		{Label: "3", X: 5.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 2}},
+		{Label: "4", X: 6.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 4}},
+		{Label: "5", X: 7.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 4}},
+		{Label: "6", X: 8.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 5}},
+		{Label: "7", X: 9.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 5}},
+		{Label: "8", X: 10.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 11}},
+		{Label: "9", X: 11.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 9}},
+		{Label: "0", X: 12.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 10}},
+		{Label: "-", X: 13.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 10}},
+		{Label: "=", X: 14.5, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 4, Col: 11}},
+		{Label: "Backspace", X: 15.5, Y: 2.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 12}},
+		{Label: "Num Lock", X: 21, Y: 2.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 16}},
+		{Label: "Tab", X: 2.5, Y: 3.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 0}},
+		{Label: "Q", X: 4, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 0}},
+		{Label: "W", X: 5, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 1}},
+		{Label: "E", X: 6, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 2}},
+		{Label: "R", X: 7, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 4}},
+		{Label: "T", X: 8, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 4}},
+		{Label: "Y", X: 9, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 5}},
+		{Label: "U", X: 10, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 5}},
+		{Label: "I", X: 11, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 11}},
+		{Label: "O", X: 12, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 9}},
+		{Label: "P", X: 13, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 10}},
+		{Label: "[", X: 14, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 10}},
+		{Label: "]", X: 15, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 11}},
+		{Label: "Enter", X: 16.25, Y: 3.5, W: 1.25, H: 2, Pos: blusb.MatrixPos{Row: 5, Col: 16}},
+		{Label: "KP 7", X: 21, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 16}},
+		{Label: "KP 8", X: 22, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 17}},
+		{Label: "KP 9", X: 23, Y: 3.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 15}},
+		{Label: "Caps Lock", X: 2.5, Y: 4.5, W: 1.75, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 1}},
+		{Label: "A", X: 4.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 0}},
+		{Label: "S", X: 5.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 1}},
+		{Label: "D", X: 6.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 2}},
+		{Label: "F", X: 7.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 4}},
+		{Label: "G", X: 8.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 4}},
+		{Label: "H", X: 9.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 5}},
+		{Label: "J", X: 10.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 5}},
+		{Label: "K", X: 11.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 11}},
+		{Label: "L", X: 12.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 9}},
+		{Label: ";", X: 13.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 10}},
+		{Label: "'", X: 14.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 10}},
+		{Label: "#", X: 15.25, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 10}},
+		{Label: "KP 4", X: 21, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 16}},
+		{Label: "KP 5", X: 22, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 17}},
+		{Label: "KP 6", X: 23, Y: 4.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 15}},
+		{Label: "Left Shift", X: 2.5, Y: 5.5, W: 1.25, H: 1, Pos: blusb.MatrixPos{Row: 2, Col: 3}},
+		{Label: "\\ |", X: 3.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 1}},
+		{Label: "Z", X: 4.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 0}},
+		{Label: "X", X: 5.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 1}},
+		{Label: "C", X: 6.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 2}},
+		{Label: "V", X: 7.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 4}},
+		{Label: "B", X: 8.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 4}},
+		{Label: "N", X: 9.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 5}},
+		{Label: "M", X: 10.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 5}},
+		{Label: ",", X: 11.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 11}},
+		{Label: ".", X: 12.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 9}},
+		{Label: "/", X: 13.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 10}},
+		{Label: "Right Shift", X: 14.75, Y: 5.5, W: 2.75, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 3}},
+		{Label: "Up", X: 18.75, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 5, Col: 13}},
+		{Label: "KP 1", X: 21, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 16}},
+		{Label: "KP 2", X: 22, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 17}},
+		{Label: "KP 3", X: 23, Y: 5.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 6, Col: 15}},
+		{Label: "KP Enter", X: 24, Y: 5.5, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 6, Col: 14}},
+		{Label: "Left Ctrl", X: 2.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 3, Col: 18}},
+		{Label: "Left Alt", X: 5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 13}},
+		{Label: "Space", X: 6.5, Y: 6.5, W: 7, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 19}},
+		{Label: "Right Alt", X: 13.5, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 13}},
+		{Label: "Right Ctrl", X: 16, Y: 6.5, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 18}},
+		{Label: "Left", X: 17.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 14}},
+		{Label: "Down", X: 18.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 16}},
+		{Label: "Right", X: 19.75, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 17}},
+		{Label: "KP 0", X: 21, Y: 6.5, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 17}},
+		{Label: "KP .", X: 23, Y: 6.5, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 7, Col: 15}},
+	},
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"testing"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// Each built-in layout must have a key for every position its default layers
+// assign, except for positions its board doesn't have, and every key must be
+// assigned by the first layer.
+func TestBuiltinLayers(t *testing.T) {
+	tests := []struct {
+		layout  string
+		layers  string
+		omitted []blusb.MatrixPos
+	}{
+		{"ansi", "ansi", []blusb.MatrixPos{{Row: 0, Col: 12}, {Row: 6, Col: 5}}},
+		{"iso", "iso", nil},
+		{"m4g-iso", "m4g_iso", nil},
+		{"122-iso-1", "122_iso_default1", nil},
+		{"122-iso-2", "122_iso_default2", nil},
+		{"122-iso-3", "122_iso_default3", nil},
+		{"122-iso-4", "122_iso_default4", nil},
+	}
+
+	if len(tests) != len(Names()) {
+		t.Errorf("%d layouts tested, want %d", len(tests), len(Names()))
+	}
+	for _, test := range tests {
+		l, err := Get(test.layout)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		ls := readDefaultLayers(t, test.layers)
+
+		for _, k := range l.Keys {
+			if ls[0].Matrix[k.Pos.Row][k.Pos.Col] == 0 {
+				t.Errorf("%s: %s at %s is unassigned", test.layout, k.Label, k.Pos)
+			}
+		}
+
+		omitted := map[blusb.MatrixPos]bool{}
+		for _, p := range test.omitted {
+			if _, ok := l.Key(p); ok {
+				t.Errorf("%s: omitted %s is a key", test.layout, p)
+			}
+			if ls[0].Matrix[p.Row][p.Col] == 0 {
+				t.Errorf("%s: omitted %s is unassigned", test.layout, p)
+			}
+			omitted[p] = true
+		}
+		for i := range ls {
+			for r := range ls[i].Matrix {
+				for c, kc := range ls[i].Matrix[r] {
+					p := blusb.MatrixPos{Row: r, Col: c}
+					if _, ok := l.Key(p); kc != 0 && !ok && !omitted[p] {
+						t.Errorf("%s: layer %d %s is %s but isn't a key", test.layout, i+1, p, kc)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,518 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// QMK keyboard the keymaps are written for.  It's the Blusb controller
+// under keyboards/ibm/model_m/blusb in the QMK firmware repository,
+// https://github.com/qmk/qmk_firmware.
+const qmkKeyboard = "ibm/model_m/blusb"
+
+// qmkLayout is a QMK LAYOUT macro and the labels of the keys it takes, in
+// argument order.  The order is QMK's and doesn't always match the order of
+// the layout keys, e.g. ISO Enter comes after "#" at the end of the home row.
+type qmkLayout struct {
+	macro string
+	order []string
+}
+
+// QMK LAYOUT arguments for keys the Model M doesn't have.  They're always
+// KC_NO.
+var qmkNoKeys = map[string]bool{
+	"Left GUI":  true,
+	"Right GUI": true,
+	"Menu":      true,
+}
+
+// The QMK layouts are the fullsize_ansi and fullsize_iso community layouts
+// from the layouts/default directory of the QMK firmware repository.
+var (
+	qmkANSI = qmkLayout{
+		macro: "LAYOUT_fullsize_ansi",
+		order: []string{
+			"Esc", "F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12", "Print Screen", "Scroll Lock", "Pause",
+			"`", "1", "2", "3", "4", "5", "6", "7", "8", "9", "0", "-", "=", "Backspace", "Insert", "Home", "Page Up", "Num Lock", "KP /", "KP *", "KP -",
+			"Tab", "Q", "W", "E", "R", "T", "Y", "U", "I", "O", "P", "[", "]", "\\", "Delete", "End", "Page Down", "KP 7", "KP 8", "KP 9", "KP +",
+			"Caps Lock", "A", "S", "D", "F", "G", "H", "J", "K", "L", ";", "'", "Enter", "KP 4", "KP 5", "KP 6",
+			"Left Shift", "Z", "X", "C", "V", "B", "N", "M", ",", ".", "/", "Right Shift", "Up", "KP 1", "KP 2", "KP 3", "KP Enter",
+			"Left Ctrl", "Left GUI", "Left Alt", "Space", "Right Alt", "Right GUI", "Menu", "Right Ctrl", "Left", "Down", "Right", "KP 0", "KP .",
+		},
+	}
+	qmkISO = qmkLayout{
+		macro: "LAYOUT_fullsize_iso",
+		order: []string{
+			"Esc", "F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12", "Print Screen", "Scroll Lock", "Pause",
+			"`", "1", "2", "3", "4", "5", "6", "7", "8", "9", "0", "-", "=", "Backspace", "Insert", "Home", "Page Up", "Num Lock", "KP /", "KP *", "KP -",
+			"Tab", "Q", "W", "E", "R", "T", "Y", "U", "I", "O", "P", "[", "]", "Delete", "End", "Page Down", "KP 7", "KP 8", "KP 9", "KP +",
+			"Caps Lock", "A", "S", "D", "F", "G", "H", "J", "K", "L", ";", "'", "#", "Enter", "KP 4", "KP 5", "KP 6",
+			"Left Shift", "\\ |", "Z", "X", "C", "V", "B", "N", "M", ",", ".", "/", "Right Shift", "Up", "KP 1", "KP 2", "KP 3", "KP Enter",
+			"Left Ctrl", "Left GUI", "Left Alt", "Space", "Right Alt", "Right GUI", "Menu", "Right Ctrl", "Left", "Down", "Right", "KP 0", "KP .",
+		},
+	}
+)
+
+// qmkLayouts holds the QMK layouts of the built-in layouts that have one.
+// The M4G is wired differently but has the same keys as the ISO.
+var qmkLayouts = map[string]qmkLayout{
+	"ansi":    qmkANSI,
+	"iso":     qmkISO,
+	"m4g-iso": qmkISO,
+}
+
+// qmkArg is a QMK LAYOUT argument.  The key is nil if the Model M doesn't
+// have it.
+type qmkArg struct {
+	label string
+	key   *Key
+}
+
+// qmkArgs returns the QMK LAYOUT macro for the layout and its arguments in
+// order.
+func (l Layout) qmkArgs() (string, []qmkArg, error) {
+	ql, ok := qmkLayouts[l.Name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrNoQMKLayout, l.Name)
+	}
+
+	args := make([]qmkArg, len(ql.order))
+	for i, label := range ql.order {
+		args[i].label = label
+		if qmkNoKeys[label] {
+			continue
+		}
+		k, ok := l.keyByLabel(label)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s has no %q key", ErrNoQMKLayout, l.Name, label)
+		}
+		args[i].key = &k
+	}
+
+	return ql.macro, args, nil
+}
+
+// keyByLabel returns the key with the label.
+func (l Layout) keyByLabel(label string) (Key, bool) {
+	for _, k := range l.Keys {
+		if k.Label == label {
+			return k, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// QMKKeymap represents a QMK keymap.json document.  Each layer lists its
+// keycodes in LAYOUT order.
+type QMKKeymap struct {
+	Version  int        `json:"version,omitempty"`
+	Keyboard string     `json:"keyboard"`
+	Keymap   string     `json:"keymap"`
+	Layout   string     `json:"layout"`
+	Layers   [][]string `json:"layers"`
+	Author   string     `json:"author,omitempty"`
+	Notes    string     `json:"notes,omitempty"`
+}
+
+// IsQMK indicates if the data looks like a QMK keymap.json document.
+func IsQMK(data []byte) bool {
+	var doc struct {
+		Layout *string
+		Layers json.RawMessage
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+
+	return doc.Layout != nil && doc.Layers != nil
+}
+
+// MarshalQMK encodes the layers as a QMK keymap.json document.  Only layouts
+// with a QMK LAYOUT macro are supported and the keys the Model M doesn't have
+// are KC_NO.  Keycodes that QMK doesn't have are reported with
+// ErrNoQMKKeycode.
+func (l Layout) MarshalQMK(ls blusb.Layers) ([]byte, error) {
+	macro, args, err := l.qmkArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := QMKKeymap{
+		Version:  1,
+		Keyboard: qmkKeyboard,
+		Keymap:   "default",
+		Layout:   macro,
+		Layers:   make([][]string, len(ls)),
+	}
+
+	var bad []string
+	for i, layer := range ls {
+		doc.Layers[i] = make([]string, len(args))
+		for j, a := range args {
+			if a.key == nil {
+				doc.Layers[i][j] = qmkNames[0]
+				continue
+			}
+			kc := layer.Matrix[a.key.Pos.Row][a.key.Pos.Col]
+			name, ok := qmkName(kc)
+			if !ok {
+				bad = append(bad, fmt.Sprintf("layer %d %q %s", i+1, a.label, kc))
+			}
+			doc.Layers[i][j] = name
+		}
+	}
+	if len(bad) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoQMKKeycode, strings.Join(bad, ", "))
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// UnmarshalQMK decodes a QMK keymap.json document into layers.  The document
+// must be for the layout's QMK LAYOUT macro and each layer must have one
+// keycode for every argument.  Matrix positions that aren't in the layout
+// are left as NONE.  QMK keycodes that the Blusb doesn't have, like KC_TRNS
+// or layer keys, and anything other than KC_NO for keys the Model M doesn't
+// have are reported with ErrNoBlusbKeycode rather than dropped.
+func (l Layout) UnmarshalQMK(data []byte) (blusb.Layers, error) {
+	macro, args, err := l.qmkArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc QMKKeymap
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQMK, err)
+	}
+	if doc.Layout != macro {
+		return nil, fmt.Errorf("%w: layout is %q but %s is %q", ErrInvalidQMK, doc.Layout, l.Name, macro)
+	}
+	if len(doc.Layers) == 0 {
+		return nil, fmt.Errorf("%w: no layers", ErrInvalidQMK)
+	}
+
+	ls := make(blusb.Layers, len(doc.Layers))
+	var bad []string
+	for i, names := range doc.Layers {
+		if len(names) != len(args) {
+			return nil, fmt.Errorf("%w: layer %d has %d keys but %s has %d",
+				ErrInvalidQMK, i+1, len(names), macro, len(args))
+		}
+		for j, name := range names {
+			a := args[j]
+			kc, ok := qmkKeycode(name)
+			switch {
+			case !ok:
+				bad = append(bad, fmt.Sprintf("layer %d %q %s", i+1, a.label, name))
+			case a.key == nil:
+				if kc != 0 {
+					bad = append(bad, fmt.Sprintf("layer %d %q %s (the Model M has no such key)", i+1, a.label, name))
+				}
+			default:
+				ls[i].Matrix[a.key.Pos.Row][a.key.Pos.Col] = kc
+			}
+		}
+	}
+	if len(bad) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoBlusbKeycode, strings.Join(bad, ", "))
+	}
+
+	return ls, nil
+}
+
+// qmkName returns the QMK name of the keycode.
+func qmkName(kc blusb.Keycode) (string, bool) {
+	if kc.IsModifier() {
+		for i, name := range qmkModifierNames {
+			if kc.Modifier() == 1<<i {
+				return name, true
+			}
+		}
+		// Modifier combinations are only possible with QMK mod-tap and
+		// friends which don't map back.
+		return "", false
+	}
+	if kc>>8 != 0 {
+		return "", false
+	}
+	if u := kc.Usage(); u >= 0xe0 && u <= 0xe7 {
+		// The QMK modifier names are taken by the modifier keys so the
+		// plain usages are written as numbers, which QMK also accepts.
+		return fmt.Sprintf("0x%02X", u), true
+	}
+	name := qmkNames[kc.Usage()]
+
+	return name, name != ""
+}
+
+// qmkKeycode returns the keycode for the QMK name.  Modifiers are returned
+// as modifier keys, which is what the default layers use.  Basic keycodes can
+// also be hexadecimal numbers, e.g. 0xE0, and are returned as plain usages.
+func qmkKeycode(name string) (blusb.Keycode, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if strings.HasPrefix(name, "0X") {
+		u, err := strconv.ParseUint(name[2:], 16, 8)
+		return blusb.Keycode(u), err == nil
+	}
+	kc, ok := qmkValues[name]
+	return kc, ok
+}
+
+// QMK modifier names in Modifier bit order
+var qmkModifierNames = [...]string{"KC_LCTL", "KC_LSFT", "KC_LALT", "KC_LGUI", "KC_RCTL", "KC_RSFT", "KC_RALT", "KC_RGUI"}
+
+// QMK basic keycode names by HID usage.  Usages QMK doesn't have are left
+// empty.
+var qmkNames = func() (names [256]string) {
+	names[0x00] = "KC_NO"
+	for u := 0x04; u <= 0x1d; u++ {
+		names[u] = "KC_" + string(rune('A'+u-0x04))
+	}
+	for u := 0x1e; u <= 0x26; u++ {
+		names[u] = fmt.Sprintf("KC_%d", u-0x1d)
+	}
+	names[0x27] = "KC_0"
+	for u := 0x3a; u <= 0x45; u++ {
+		names[u] = fmt.Sprintf("KC_F%d", u-0x39)
+	}
+	for u := 0x59; u <= 0x61; u++ {
+		names[u] = fmt.Sprintf("KC_P%d", u-0x58)
+	}
+	names[0x62] = "KC_P0"
+	for u := 0x68; u <= 0x73; u++ {
+		names[u] = fmt.Sprintf("KC_F%d", u-0x68+13)
+	}
+	for u := 0x87; u <= 0x8f; u++ {
+		names[u] = fmt.Sprintf("KC_INT%d", u-0x86)
+	}
+	for u := 0x90; u <= 0x98; u++ {
+		names[u] = fmt.Sprintf("KC_LNG%d", u-0x8f)
+	}
+
+	for u, name := range map[int]string{
+		0x28: "KC_ENT",
+		0x29: "KC_ESC",
+		0x2a: "KC_BSPC",
+		0x2b: "KC_TAB",
+		0x2c: "KC_SPC",
+		0x2d: "KC_MINS",
+		0x2e: "KC_EQL",
+		0x2f: "KC_LBRC",
+		0x30: "KC_RBRC",
+		0x31: "KC_BSLS",
+		0x32: "KC_NUHS",
+		0x33: "KC_SCLN",
+		0x34: "KC_QUOT",
+		0x35: "KC_GRV",
+		0x36: "KC_COMM",
+		0x37: "KC_DOT",
+		0x38: "KC_SLSH",
+		0x39: "KC_CAPS",
+		0x46: "KC_PSCR",
+		0x47: "KC_SCRL",
+		0x48: "KC_PAUS",
+		0x49: "KC_INS",
+		0x4a: "KC_HOME",
+		0x4b: "KC_PGUP",
+		0x4c: "KC_DEL",
+		0x4d: "KC_END",
+		0x4e: "KC_PGDN",
+		0x4f: "KC_RGHT",
+		0x50: "KC_LEFT",
+		0x51: "KC_DOWN",
+		0x52: "KC_UP",
+		0x53: "KC_NUM",
+		0x54: "KC_PSLS",
+		0x55: "KC_PAST",
+		0x56: "KC_PMNS",
+		0x57: "KC_PPLS",
+		0x58: "KC_PENT",
+		0x63: "KC_PDOT",
+		0x64: "KC_NUBS",
+		0x65: "KC_APP",
+		0x66: "KC_KB_POWER",
+		0x67: "KC_PEQL",
+		0x74: "KC_EXEC",
+		0x75: "KC_HELP",
+		0x76: "KC_MENU",
+		0x77: "KC_SLCT",
+		0x78: "KC_STOP",
+		0x79: "KC_AGIN",
+		0x7a: "KC_UNDO",
+		0x7b: "KC_CUT",
+		0x7c: "KC_COPY",
+		0x7d: "KC_PSTE",
+		0x7e: "KC_FIND",
+		0x7f: "KC_KB_MUTE",
+		0x80: "KC_KB_VOLUME_UP",
+		0x81: "KC_KB_VOLUME_DOWN",
+		0x82: "KC_LCAP",
+		0x83: "KC_LNUM",
+		0x84: "KC_LSCR",
+		0x85: "KC_PCMM",
+		0x86: "KC_KP_EQUAL_AS400",
+		0x99: "KC_ERAS",
+		0x9a: "KC_SYRQ",
+		0x9b: "KC_CNCL",
+		0x9c: "KC_CLR",
+		0x9d: "KC_PRIR",
+		0x9e: "KC_RETN",
+		0x9f: "KC_SEPR",
+		0xa0: "KC_OUT",
+		0xa1: "KC_OPER",
+		0xa2: "KC_CLAG",
+		0xa3: "KC_CRSL",
+		0xa4: "KC_EXSL",
+	} {
+		names[u] = name
+	}
+
+	return
+}()
+
+// qmkAliases maps the long and older QMK names to the short ones.
+var qmkAliases = map[string]string{
+	"XXXXXXX":                "KC_NO",
+	"KC_ENTER":               "KC_ENT",
+	"KC_ESCAPE":              "KC_ESC",
+	"KC_BACKSPACE":           "KC_BSPC",
+	"KC_BSPACE":              "KC_BSPC",
+	"KC_SPACE":               "KC_SPC",
+	"KC_MINUS":               "KC_MINS",
+	"KC_EQUAL":               "KC_EQL",
+	"KC_LEFT_BRACKET":        "KC_LBRC",
+	"KC_LBRACKET":            "KC_LBRC",
+	"KC_RIGHT_BRACKET":       "KC_RBRC",
+	"KC_RBRACKET":            "KC_RBRC",
+	"KC_BACKSLASH":           "KC_BSLS",
+	"KC_BSLASH":              "KC_BSLS",
+	"KC_NONUS_HASH":          "KC_NUHS",
+	"KC_SEMICOLON":           "KC_SCLN",
+	"KC_SCOLON":              "KC_SCLN",
+	"KC_QUOTE":               "KC_QUOT",
+	"KC_GRAVE":               "KC_GRV",
+	"KC_ZKHK":                "KC_GRV",
+	"KC_COMMA":               "KC_COMM",
+	"KC_SLASH":               "KC_SLSH",
+	"KC_CAPS_LOCK":           "KC_CAPS",
+	"KC_CAPSLOCK":            "KC_CAPS",
+	"KC_CLCK":                "KC_CAPS",
+	"KC_PRINT_SCREEN":        "KC_PSCR",
+	"KC_PSCREEN":             "KC_PSCR",
+	"KC_SCROLL_LOCK":         "KC_SCRL",
+	"KC_SCROLLLOCK":          "KC_SCRL",
+	"KC_SLCK":                "KC_SCRL",
+	"KC_BRMD":                "KC_SCRL",
+	"KC_PAUSE":               "KC_PAUS",
+	"KC_BRK":                 "KC_PAUS",
+	"KC_BRMU":                "KC_PAUS",
+	"KC_INSERT":              "KC_INS",
+	"KC_PAGE_UP":             "KC_PGUP",
+	"KC_DELETE":              "KC_DEL",
+	"KC_PAGE_DOWN":           "KC_PGDN",
+	"KC_PGDOWN":              "KC_PGDN",
+	"KC_RIGHT":               "KC_RGHT",
+	"KC_NUM_LOCK":            "KC_NUM",
+	"KC_NUMLOCK":             "KC_NUM",
+	"KC_NLCK":                "KC_NUM",
+	"KC_KP_SLASH":            "KC_PSLS",
+	"KC_KP_ASTERISK":         "KC_PAST",
+	"KC_KP_MINUS":            "KC_PMNS",
+	"KC_KP_PLUS":             "KC_PPLS",
+	"KC_KP_ENTER":            "KC_PENT",
+	"KC_KP_1":                "KC_P1",
+	"KC_KP_2":                "KC_P2",
+	"KC_KP_3":                "KC_P3",
+	"KC_KP_4":                "KC_P4",
+	"KC_KP_5":                "KC_P5",
+	"KC_KP_6":                "KC_P6",
+	"KC_KP_7":                "KC_P7",
+	"KC_KP_8":                "KC_P8",
+	"KC_KP_9":                "KC_P9",
+	"KC_KP_0":                "KC_P0",
+	"KC_KP_DOT":              "KC_PDOT",
+	"KC_NONUS_BACKSLASH":     "KC_NUBS",
+	"KC_NONUS_BSLASH":        "KC_NUBS",
+	"KC_APPLICATION":         "KC_APP",
+	"KC_POWER":               "KC_KB_POWER",
+	"KC_KP_EQUAL":            "KC_PEQL",
+	"KC_EXECUTE":             "KC_EXEC",
+	"KC_SELECT":              "KC_SLCT",
+	"KC_AGAIN":               "KC_AGIN",
+	"KC_PASTE":               "KC_PSTE",
+	"KC__MUTE":               "KC_KB_MUTE",
+	"KC__VOLUP":              "KC_KB_VOLUME_UP",
+	"KC__VOLDOWN":            "KC_KB_VOLUME_DOWN",
+	"KC_LOCKING_CAPS_LOCK":   "KC_LCAP",
+	"KC_LOCKING_NUM_LOCK":    "KC_LNUM",
+	"KC_LOCKING_SCROLL_LOCK": "KC_LSCR",
+	"KC_KP_COMMA":            "KC_PCMM",
+	"KC_RO":                  "KC_INT1",
+	"KC_KANA":                "KC_INT2",
+	"KC_JYEN":                "KC_INT3",
+	"KC_HENK":                "KC_INT4",
+	"KC_MHEN":                "KC_INT5",
+	"KC_HAEN":                "KC_LNG1",
+	"KC_HANJ":                "KC_LNG2",
+	"KC_ALTERNATE_ERASE":     "KC_ERAS",
+	"KC_SYSTEM_REQUEST":      "KC_SYRQ",
+	"KC_SYSREQ":              "KC_SYRQ",
+	"KC_CANCEL":              "KC_CNCL",
+	"KC_CLEAR":               "KC_CLR",
+	"KC_PRIOR":               "KC_PRIR",
+	"KC_RETURN":              "KC_RETN",
+	"KC_SEPARATOR":           "KC_SEPR",
+	"KC_CLEAR_AGAIN":         "KC_CLAG",
+	"KC_CRSEL":               "KC_CRSL",
+	"KC_EXSEL":               "KC_EXSL",
+	"KC_LEFT_CTRL":           "KC_LCTL",
+	"KC_LCTRL":               "KC_LCTL",
+	"KC_LEFT_SHIFT":          "KC_LSFT",
+	"KC_LSHIFT":              "KC_LSFT",
+	"KC_LEFT_ALT":            "KC_LALT",
+	"KC_LOPT":                "KC_LALT",
+	"KC_LEFT_GUI":            "KC_LGUI",
+	"KC_LCMD":                "KC_LGUI",
+	"KC_LWIN":                "KC_LGUI",
+	"KC_RIGHT_CTRL":          "KC_RCTL",
+	"KC_RCTRL":               "KC_RCTL",
+	"KC_RIGHT_SHIFT":         "KC_RSFT",
+	"KC_RSHIFT":              "KC_RSFT",
+	"KC_RIGHT_ALT":           "KC_RALT",
+	"KC_ROPT":                "KC_RALT",
+	"KC_ALGR":                "KC_RALT",
+	"KC_RIGHT_GUI":           "KC_RGUI",
+	"KC_RCMD":                "KC_RGUI",
+	"KC_RWIN":                "KC_RGUI",
+}
+
+// qmkValues maps QMK names, including aliases, to keycodes.
+var qmkValues = func() map[string]blusb.Keycode {
+	values := map[string]blusb.Keycode{}
+	for u, name := range qmkNames {
+		if name != "" {
+			values[name] = blusb.Keycode(u)
+		}
+	}
+	for i, name := range qmkModifierNames {
+		values[name] = blusb.ModifierKey(1 << i)
+	}
+	for alias, name := range qmkAliases {
+		values[alias] = values[name]
+	}
+
+	return values
+}()
@@ -0,0 +1,264 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// readDefaultLayers reads a default layers file from the layers directory.
+func readDefaultLayers(t *testing.T, name string) blusb.Layers {
+	t.Helper()
+
+	text, err := os.ReadFile("../../layers/ibm_model_m_blusb_universal_" + name + "_hex.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ls blusb.Layers
+	if err := ls.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+
+	return ls
+}
+
+// qmkDefaultLayers reads the default layers for a layout as QMK would map
+// them.
+func qmkDefaultLayers(t *testing.T, l Layout, name string) blusb.Layers {
+	t.Helper()
+
+	ls := readDefaultLayers(t, name)
+	if k, ok := l.keyByLabel("#"); ok {
+		ls[0].Matrix[k.Pos.Row][k.Pos.Col], _ = blusb.ParseKeycode("NONUS_HASH")
+	}
+
+	return ls
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// The QMK keymaps in testdata are the default keymaps of QMK's fullsize
+// community layouts.  They match the default layers except that:
+//
+//   - The GUI and Menu keys, which the Model M doesn't have, are KC_NO.
+//   - QMK's ISO keymap sends NONUS_HASH from the "#" key where the default
+//     layers send BSLASH.
+var qmkTests = []struct {
+	layout string
+	keymap string
+	layers string
+}{
+	{"ansi", "default_fullsize_ansi.json", "ansi"},
+	{"iso", "default_fullsize_iso.json", "iso"},
+	{"m4g-iso", "default_fullsize_iso.json", "m4g_iso"},
+}
+
+// qmkKeymap reads a QMK keymap from testdata with the keys the Model M
+// doesn't have set to KC_NO.
+func qmkKeymap(t *testing.T, name string) []byte {
+	t.Helper()
+
+	return []byte(strings.NewReplacer(
+		`"KC_LGUI"`, `"KC_NO"`,
+		`"KC_RGUI"`, `"KC_NO"`,
+		`"KC_APP"`, `"KC_NO"`,
+	).Replace(string(readTestdata(t, name))))
+}
+
+func TestUnmarshalQMK(t *testing.T) {
+	for _, test := range qmkTests {
+		t.Run(test.layout, func(t *testing.T) {
+			l, _ := Get(test.layout)
+			want := qmkDefaultLayers(t, l, test.layers)
+
+			got, err := l.UnmarshalQMK(qmkKeymap(t, test.keymap))
+			if err != nil {
+				t.Fatalf("UnmarshalQMK: %s", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("%d layers, want 1", len(got))
+			}
+			for _, k := range l.Keys {
+				g, w := got[0].Matrix[k.Pos.Row][k.Pos.Col], want[0].Matrix[k.Pos.Row][k.Pos.Col]
+				if g != w {
+					t.Errorf("%s at %s is %s, want %s", k.Label, k.Pos, g, w)
+				}
+			}
+		})
+	}
+}
+
+// QMK's own keymaps have keycodes for the GUI and Menu keys, which can't be
+// imported.
+func TestUnmarshalQMKNoKeys(t *testing.T) {
+	l, _ := Get("ansi")
+	_, err := l.UnmarshalQMK(readTestdata(t, "default_fullsize_ansi.json"))
+	if !errors.Is(err, ErrNoBlusbKeycode) {
+		t.Fatalf("error %v, want %v", err, ErrNoBlusbKeycode)
+	}
+	for _, name := range []string{"KC_LGUI", "KC_RGUI", "KC_APP"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q doesn't mention %s", err, name)
+		}
+	}
+}
+
+func TestUnmarshalQMKISOEnter(t *testing.T) {
+	l, _ := Get("iso")
+	ls, err := l.UnmarshalQMK(qmkKeymap(t, "default_fullsize_iso.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ISO Enter is the last home row argument, after KC_NUHS.
+	for label, want := range map[string]string{"Enter": "ENTER", "#": "NONUS_HASH", "]": "RBRACKET", "Delete": "DELETE"} {
+		k, _ := l.keyByLabel(label)
+		if got := ls[0].Matrix[k.Pos.Row][k.Pos.Col].String(); got != want {
+			t.Errorf("%s is %s, want %s", label, got, want)
+		}
+	}
+}
+
+func TestMarshalQMK(t *testing.T) {
+	for _, test := range qmkTests {
+		t.Run(test.layout, func(t *testing.T) {
+			l, _ := Get(test.layout)
+
+			data, err := l.MarshalQMK(qmkDefaultLayers(t, l, test.layers))
+			if err != nil {
+				t.Fatalf("MarshalQMK: %s", err)
+			}
+
+			var got, want QMKKeymap
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(qmkKeymap(t, test.keymap), &want); err != nil {
+				t.Fatal(err)
+			}
+			if got.Keyboard != want.Keyboard || got.Layout != want.Layout {
+				t.Errorf("keyboard and layout are %s %s, want %s %s",
+					got.Keyboard, got.Layout, want.Keyboard, want.Layout)
+			}
+			if !reflect.DeepEqual(got.Layers, want.Layers) {
+				t.Errorf("layers are\n%v\nwant\n%v", got.Layers, want.Layers)
+			}
+		})
+	}
+}
+
+// Exporting and importing keeps every keycode QMK has, including plain
+// modifier usages which are distinct from modifier keys.
+func TestQMKRoundTrip(t *testing.T) {
+	l, _ := Get("iso")
+	ls := readDefaultLayers(t, "iso")
+	for i, label := range []string{"A", "S", "D", "F", "G", "H", "J", "K"} {
+		k, _ := l.keyByLabel(label)
+		ls[0].Matrix[k.Pos.Row][k.Pos.Col] = blusb.Keycode(0xe0 + i)
+	}
+	k, _ := l.keyByLabel("L")
+	ls[0].Matrix[k.Pos.Row][k.Pos.Col] = blusb.ModifierKey(blusb.RGUI)
+
+	data, err := l.MarshalQMK(ls)
+	if err != nil {
+		t.Fatalf("MarshalQMK: %s", err)
+	}
+	got, err := l.UnmarshalQMK(data)
+	if err != nil {
+		t.Fatalf("UnmarshalQMK: %s", err)
+	}
+	for _, k := range l.Keys {
+		g, w := got[0].Matrix[k.Pos.Row][k.Pos.Col], ls[0].Matrix[k.Pos.Row][k.Pos.Col]
+		if g != w {
+			t.Errorf("%s is %s (%#x), want %s (%#x)", k.Label, g, uint16(g), w, uint16(w))
+		}
+	}
+}
+
+func TestQMKErrors(t *testing.T) {
+	iso, _ := Get("iso")
+	ansi, _ := Get("ansi")
+	isoKeymap := string(qmkKeymap(t, "default_fullsize_iso.json"))
+
+	tests := []struct {
+		name string
+		l    Layout
+		data string
+		err  error
+	}{
+		{"not JSON", iso, "layers", ErrInvalidQMK},
+		{"wrong layout", ansi, isoKeymap, ErrInvalidQMK},
+		{"no layers", iso, `{"layout": "LAYOUT_fullsize_iso", "layers": []}`, ErrInvalidQMK},
+		{"short layer", iso, `{"layout": "LAYOUT_fullsize_iso", "layers": [["KC_ESC"]]}`, ErrInvalidQMK},
+		{"transparent", iso, strings.Replace(isoKeymap, `"KC_A"`, `"KC_TRNS"`, 1), ErrNoBlusbKeycode},
+		{"layer tap", iso, strings.Replace(isoKeymap, `"KC_CAPS"`, `"LT(1, KC_CAPS)"`, 1), ErrNoBlusbKeycode},
+		{"number too big", iso, strings.Replace(isoKeymap, `"KC_A"`, `"0x100"`, 1), ErrNoBlusbKeycode},
+		{"no QMK layout", iso122_1, isoKeymap, ErrNoQMKLayout},
+	}
+
+	for _, test := range tests {
+		if _, err := test.l.UnmarshalQMK([]byte(test.data)); !errors.Is(err, test.err) {
+			t.Errorf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	ls := blusb.Layers{blusb.Layer{}}
+	k, _ := iso.keyByLabel("A")
+	ls[0].Matrix[k.Pos.Row][k.Pos.Col] = blusb.ModifierKey(blusb.LCtrl | blusb.LShift)
+	if _, err := iso.MarshalQMK(ls); !errors.Is(err, ErrNoQMKKeycode) {
+		t.Errorf("modifier combination: error %v, want %v", err, ErrNoQMKKeycode)
+	}
+	if _, err := iso122_1.MarshalQMK(ls); !errors.Is(err, ErrNoQMKLayout) {
+		t.Errorf("no QMK layout: error %v, want %v", err, ErrNoQMKLayout)
+	}
+}
+
+// Every layout key must be a QMK layout argument exactly once and the other
+// arguments must be keys the Model M doesn't have.
+func TestQMKLayoutOrder(t *testing.T) {
+	want := map[string]int{"LAYOUT_fullsize_ansi": 104, "LAYOUT_fullsize_iso": 105}
+
+	for name := range qmkLayouts {
+		l, _ := Get(name)
+		macro, args, err := l.qmkArgs()
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if len(args) != want[macro] {
+			t.Errorf("%s: %s has %d arguments, want %d", name, macro, len(args), want[macro])
+		}
+
+		seen := map[blusb.MatrixPos]bool{}
+		for _, a := range args {
+			if a.key == nil {
+				continue
+			}
+			if seen[a.key.Pos] {
+				t.Errorf("%s: %s is an argument more than once", name, a.label)
+			}
+			seen[a.key.Pos] = true
+		}
+		if len(seen) != len(l.Keys) {
+			t.Errorf("%s: %d key arguments but %d keys", name, len(seen), len(l.Keys))
+		}
+	}
+}
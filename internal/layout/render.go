@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"bytes"
+	"math"
+	"strings"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// Character cells per key unit
+const (
+	renderScaleX = 7
+	renderScaleY = 2
+)
+
+// minKeySize is the smallest key width or height, in key units, that renders
+// with its borders on separate rows and columns.
+const minKeySize = 1.0 / renderScaleY
+
+// Keycap border characters
+type border struct {
+	h, v, corner rune
+}
+
+var (
+	plainBorder     = border{h: '-', v: '|', corner: '+'}
+	highlightBorder = border{h: '=', v: '#', corner: '#'}
+)
+
+// canvas is a grid of characters.
+type canvas [][]rune
+
+func newCanvas(w, h int) canvas {
+	c := make(canvas, h)
+	for y := range c {
+		c[y] = []rune(strings.Repeat(" ", w))
+	}
+
+	return c
+}
+
+// in indicates if the cell is on the canvas.
+func (c canvas) in(x, y int) bool {
+	return y >= 0 && y < len(c) && x >= 0 && x < len(c[y])
+}
+
+// set sets a border character.  Crossing borders become corners, and a
+// highlighted border wins over a plain one.  Cells off the canvas are
+// skipped.
+func (c canvas) set(x, y int, r rune, b border) {
+	if !c.in(x, y) {
+		return
+	}
+
+	switch prev := c[y][x]; {
+	case prev == ' ' || prev == r:
+	case prev == highlightBorder.h || prev == highlightBorder.v || prev == highlightBorder.corner:
+		if b != highlightBorder {
+			return
+		}
+		r = b.corner
+	default:
+		r = b.corner
+	}
+	c[y][x] = r
+}
+
+// box draws a keycap with its label centered inside.  It's clipped to the
+// canvas and the label is left out if there's no room for it.
+func (c canvas) box(x0, y0, x1, y1 int, label string, b border) {
+	for y := y0 + 1; y < y1; y++ {
+		for x := x0 + 1; x < x1; x++ {
+			if c.in(x, y) {
+				c[y][x] = ' '
+			}
+		}
+	}
+	for x := x0 + 1; x < x1; x++ {
+		c.set(x, y0, b.h, b)
+		c.set(x, y1, b.h, b)
+	}
+	for y := y0 + 1; y < y1; y++ {
+		c.set(x0, y, b.v, b)
+		c.set(x1, y, b.v, b)
+	}
+	for _, p := range [][2]int{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+		c.set(p[0], p[1], b.corner, b)
+	}
+
+	width := x1 - x0 - 1
+	if width <= 0 || y1-y0 < 2 {
+		return
+	}
+	if len(label) > width {
+		label = label[:width]
+	}
+	y := (y0 + y1) / 2
+	for i, r := range []rune(label) {
+		if x := x0 + 1 + (width-len(label))/2 + i; c.in(x, y) {
+			c[y][x] = r
+		}
+	}
+}
+
+func (c canvas) String() string {
+	buf := &bytes.Buffer{}
+	for _, row := range c {
+		buf.WriteString(strings.TrimRight(string(row), " "))
+		buf.WriteByte('\n')
+	}
+
+	return buf.String()
+}
+
+func scale(v, s float64) int {
+	return int(math.Round(v * s))
+}
+
+// Render draws the layout as ASCII art with each keycap labeled by the
+// keycode the layer assigns to it.  If base isn't nil then keys that differ
+// from it are highlighted with a double border.
+func (l Layout) Render(layer blusb.Layer, base *blusb.Layer) string {
+	w, h := l.Size()
+	c := newCanvas(scale(w, renderScaleX)+1, scale(h, renderScaleY)+1)
+
+	// Highlighted keys are drawn last so their borders win.
+	var highlights []Key
+	for _, k := range l.Keys {
+		kc := layer.Matrix[k.Pos.Row][k.Pos.Col]
+		if base != nil && base.Matrix[k.Pos.Row][k.Pos.Col] != kc {
+			highlights = append(highlights, k)
+			continue
+		}
+		l.drawKey(c, k, kc, plainBorder)
+	}
+	for _, k := range highlights {
+		l.drawKey(c, k, layer.Matrix[k.Pos.Row][k.Pos.Col], highlightBorder)
+	}
+
+	return c.String()
+}
+
+func (l Layout) drawKey(c canvas, k Key, kc blusb.Keycode, b border) {
+	label := ""
+	if kc != 0 {
+		label = kc.String()
+	}
+
+	c.box(scale(k.X, renderScaleX), scale(k.Y, renderScaleY),
+		scale(k.X+k.W, renderScaleX), scale(k.Y+k.H, renderScaleY), label, b)
+}
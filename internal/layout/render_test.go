@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+var renderTestLayout = Layout{
+	Name: "test",
+	Keys: []Key{
+		{Label: "A", X: 0, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+		{Label: "B", X: 1, Y: 0, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+		{Label: "C", X: 0, Y: 1, W: 1.5, H: 1, Pos: blusb.MatrixPos{Row: 1, Col: 0}},
+	},
+}
+
+func TestRender(t *testing.T) {
+	var layer blusb.Layer
+	layer.Matrix[0][0] = 0x04
+	layer.Matrix[0][1] = 0x2a
+	layer.Matrix[1][0] = blusb.ModifierKey(blusb.LCtrl)
+
+	want := `+------+-------------+
+|  A   |   BSPACE    |
++------+---+---------+
+|  LCTRL   |
++----------+
+`
+	if got := renderTestLayout.Render(layer, nil); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+
+	base := layer
+	base.Matrix[0][1] = 0
+	want = `+------#=============#
+|  A   #   BSPACE    #
++------#####=========#
+|  LCTRL   |
++----------+
+`
+	if got := renderTestLayout.Render(layer, &base); got != want {
+		t.Errorf("Render() with a base =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// Labels that don't fit are cut off.
+func TestRenderLongLabel(t *testing.T) {
+	var layer blusb.Layer
+	layer.Matrix[0][0] = 0x47 // SCROLLLOCK
+
+	got := renderTestLayout.Render(layer, nil)
+	if line := strings.Split(got, "\n")[1]; !strings.HasPrefix(line, "|SCROLL|") {
+		t.Errorf("label line is %q", line)
+	}
+}
+
+// Odd geometry is clipped rather than drawn off the canvas.
+func TestRenderDegenerate(t *testing.T) {
+	var layer blusb.Layer
+	layer.Matrix[0][0] = 0x04
+
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{"no keys", nil},
+		{"negative position", []Key{{X: -1, Y: -1, W: 1, H: 1}}},
+		{"partly negative", []Key{{X: -0.5, Y: 0, W: 2, H: 1}, {X: 0, Y: -3, W: 1, H: 1}}},
+		{"zero size", []Key{{X: 0, Y: 0}}},
+		{"tiny", []Key{{X: 0, Y: 0, W: 0.05, H: 0.05}}},
+		{"negative size", []Key{{X: 2, Y: 2, W: -1, H: -1}}},
+		{"flat", []Key{{X: 0, Y: 0, W: 3, H: 0.25}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Layout{Keys: test.keys}.Render(layer, &blusb.Layer{})
+		})
+	}
+}
+
+// Every built-in layout draws every key it has a default assignment for.
+func TestRenderBuiltin(t *testing.T) {
+	for _, name := range Names() {
+		l, _ := Get(name)
+		var layer blusb.Layer
+		for _, k := range l.Keys {
+			layer.Matrix[k.Pos.Row][k.Pos.Col] = 0x04
+		}
+
+		if got, want := strings.Count(l.Render(layer, nil), " A "), len(l.Keys); got != want {
+			t.Errorf("%s: %d labels drawn, want %d", name, got, want)
+		}
+	}
+}
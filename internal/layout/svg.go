@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// SVG geometry in pixels
+const (
+	svgUnit    = 60 // One key unit
+	svgGap     = 3  // Space between keycaps
+	svgMargin  = 20
+	svgTitle   = 28 // Title and layer key height
+	svgFont    = 11 // Largest legend font size
+	svgPadding = 5  // Legend inset from the keycap edge
+)
+
+// Legend colors for each layer, repeating if there are more layers.
+var svgColors = []string{"#000000", "#1f5fbf", "#bf3f1f", "#2f8f2f", "#8f2fbf", "#bf8f1f"}
+
+func svgColor(i int) string {
+	return svgColors[i%len(svgColors)]
+}
+
+// svgFontSize returns a font size that fits s within width.
+func svgFontSize(s string, width, size float64) float64 {
+	// Monospace glyphs are roughly 0.6 em wide.
+	if w := float64(len(s)) * 0.6 * size; w > width {
+		size *= width / w
+	}
+
+	return math.Floor(size*10) / 10
+}
+
+// svg writes the svg element for the layers on the layout.
+func (l Layout) svg(buf *bytes.Buffer, layers blusb.Layers) {
+	w, h := l.Size()
+	width := w*svgUnit + 2*svgMargin
+	height := h*svgUnit + 2*svgMargin + svgTitle
+
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="monospace">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(buf, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+
+	// Title followed by a key to the legend colors.
+	fmt.Fprintf(buf, `<text x="%d" y="%d" font-size="14" font-weight="bold">%s</text>`+"\n",
+		svgMargin, svgMargin+4, html.EscapeString(l.Description))
+	for i := range layers {
+		fmt.Fprintf(buf, `<text x="%g" y="%d" font-size="12" fill="%s">Layer %d</text>`+"\n",
+			width-svgMargin-float64(len(layers)-i)*70, svgMargin+4, svgColor(i), i+1)
+	}
+
+	top := float64(svgMargin + svgTitle)
+	for _, k := range l.Keys {
+		x := svgMargin + k.X*svgUnit + svgGap/2
+		y := top + k.Y*svgUnit + svgGap/2
+		kw := k.W*svgUnit - svgGap
+		kh := k.H*svgUnit - svgGap
+
+		fmt.Fprintf(buf, `<g><title>%s (R%d C%d)</title>`, html.EscapeString(k.Label), k.Pos.Row, k.Pos.Col)
+		fmt.Fprintf(buf, `<rect x="%g" y="%g" width="%g" height="%g" rx="4" fill="#f4f4f4" stroke="#808080"/>`, x, y, kw, kh)
+
+		// One legend line per layer stacked from the top of the keycap.
+		line := math.Min(svgFont+2, (kh-2*svgPadding)/float64(len(layers)))
+		for i, layer := range layers {
+			kc := layer.Matrix[k.Pos.Row][k.Pos.Col]
+			if kc == 0 {
+				continue
+			}
+			s := kc.String()
+			weight := "normal"
+			if i == 0 {
+				weight = "bold"
+			}
+			fmt.Fprintf(buf, `<text x="%g" y="%g" font-size="%g" font-weight="%s" fill="%s">%s</text>`,
+				x+svgPadding, y+svgPadding+line*float64(i+1)-2,
+				svgFontSize(s, kw-2*svgPadding, math.Min(svgFont, line)), weight, svgColor(i), html.EscapeString(s))
+		}
+		buf.WriteString("</g>\n")
+	}
+
+	buf.WriteString("</svg>\n")
+}
+
+// SVG returns an SVG drawing of the layout with the legend of every layer
+// stacked on each keycap.  The first layer is bold and each layer has its
+// own color.
+func (l Layout) SVG(layers blusb.Layers) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	l.svg(buf, layers)
+
+	return buf.Bytes()
+}
+
+// HTML returns a standalone HTML page containing the SVG drawing, sized to
+// print on a landscape page.
+func (l Layout) HTML(layers blusb.Layers) []byte {
+	title := html.EscapeString(l.Description)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+@page { size: landscape; margin: 1cm; }
+body { margin: 0; }
+svg { width: 100%%; height: auto; }
+</style>
+</head>
+<body>
+`, title)
+	l.svg(buf, layers)
+	buf.WriteString("</body>\n</html>\n")
+
+	return buf.Bytes()
+}
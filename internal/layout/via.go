@@ -0,0 +1,316 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+// VIADefinition represents a VIA or Vial keyboard definition.  Only the
+// parts that describe the physical layout are kept.
+type VIADefinition struct {
+	Name      string `json:"name"`
+	VendorID  string `json:"vendorId,omitempty"`
+	ProductID string `json:"productId,omitempty"`
+	Matrix    struct {
+		Rows int `json:"rows"`
+		Cols int `json:"cols"`
+	} `json:"matrix"`
+	Layouts struct {
+		// KLE serialized rows.  Each key has its "row,col" matrix
+		// position as the top left legend.
+		Keymap []json.RawMessage `json:"keymap"`
+	} `json:"layouts"`
+}
+
+// kleProps represents the KLE properties that apply to the keys following
+// them in a row.  Only the ones that affect geometry and legend placement are
+// kept.
+type kleProps struct {
+	X float64 `json:"x,omitempty"`
+	Y float64 `json:"y,omitempty"`
+	W float64 `json:"w,omitempty"`
+	H float64 `json:"h,omitempty"`
+	A *int    `json:"a,omitempty"`
+
+	R  float64 `json:"r,omitempty"`
+	RX float64 `json:"rx,omitempty"`
+	RY float64 `json:"ry,omitempty"`
+
+	D bool `json:"d,omitempty"` // Decal, not a key
+}
+
+// KLE legend positions
+const (
+	kleTopLeft     = 0
+	kleCenter      = 4
+	kleBottomRight = 8
+	kleFrontCenter = 10
+
+	// Serialized index of the front center legend with the default
+	// alignment
+	kleFrontCenterIndex = 4
+)
+
+// kleDefaultAlign is the KLE default legend alignment.
+const kleDefaultAlign = 4
+
+// kleLabelMap maps the serialized legend order to legend positions for each
+// alignment.  It's the same table KLE and VIA use.
+var kleLabelMap = [8][12]int{
+	{0, 6, 2, 8, 9, 11, 3, 5, 1, 4, 7, 10},
+	{1, 7, -1, -1, 9, 11, 4, -1, -1, -1, -1, 10},
+	{3, -1, 5, -1, 9, 11, -1, -1, 4, -1, -1, 10},
+	{4, -1, -1, -1, 9, 11, -1, -1, -1, -1, -1, 10},
+	{0, 6, 2, 8, 10, -1, 3, 5, 1, 4, 7, -1},
+	{1, 7, -1, -1, 10, -1, 4, -1, -1, -1, -1, -1},
+	{3, -1, 5, -1, 10, -1, -1, -1, 4, -1, -1, -1},
+	{4, -1, -1, -1, 10, -1, -1, -1, -1, -1, -1, -1},
+}
+
+// kleLegends returns the legends of a KLE key by position.
+func kleLegends(s string, align int) (legends [12]string) {
+	if align < 0 || align >= len(kleLabelMap) {
+		align = kleDefaultAlign
+	}
+	for i, l := range strings.Split(s, "\n") {
+		if i < len(kleLabelMap[align]) && kleLabelMap[align][i] >= 0 {
+			legends[kleLabelMap[align][i]] = l
+		}
+	}
+
+	return
+}
+
+// parsePair parses a "a,b" legend.
+func parsePair(s string) (a, b int, err error) {
+	f := strings.Split(s, ",")
+	if len(f) != 2 {
+		return 0, 0, fmt.Errorf("%q isn't a comma separated pair", s)
+	}
+	if a, err = strconv.Atoi(strings.TrimSpace(f[0])); err != nil {
+		return
+	}
+	b, err = strconv.Atoi(strings.TrimSpace(f[1]))
+	return
+}
+
+// UnmarshalVIA decodes a VIA or Vial definition, or bare KLE serialized
+// rows, into the layout.  For layout options only the first choice is used,
+// and encoders and decals are skipped.  Keys are labeled with their front
+// legend, as written by MarshalVIA, or their row and column.  The keys are
+// moved so the layout starts at 0,0 and each must be at least half a key unit
+// wide and high so it can be drawn.
+func (l *Layout) UnmarshalVIA(data []byte) error {
+	var def VIADefinition
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &def.Layouts.Keymap); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidVIA, err)
+		}
+	} else if err := json.Unmarshal(data, &def); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidVIA, err)
+	}
+	if def.Matrix.Rows == 0 && def.Matrix.Cols == 0 {
+		def.Matrix.Rows, def.Matrix.Cols = blusb.MatrixRows, blusb.MatrixCols
+	}
+	if def.Matrix.Rows > blusb.MatrixRows || def.Matrix.Cols > blusb.MatrixCols {
+		return fmt.Errorf("%w: %dx%d matrix is larger than %dx%d",
+			ErrInvalidVIA, def.Matrix.Rows, def.Matrix.Cols, blusb.MatrixRows, blusb.MatrixCols)
+	}
+
+	var keys []Key
+	seen := map[blusb.MatrixPos]bool{}
+	align := kleDefaultAlign
+	var y float64
+	for i, raw := range def.Layouts.Keymap {
+		var row []json.RawMessage
+		if err := json.Unmarshal(raw, &row); err != nil {
+			// The first row may be keyboard metadata.
+			if i == 0 {
+				continue
+			}
+			return fmt.Errorf("%w: row %d: %s", ErrInvalidVIA, i+1, err)
+		}
+
+		x, w, h, decal := 0.0, 1.0, 1.0, false
+		for _, item := range row {
+			var s string
+			if err := json.Unmarshal(item, &s); err != nil {
+				var p kleProps
+				if err := json.Unmarshal(item, &p); err != nil {
+					return fmt.Errorf("%w: row %d: %s", ErrInvalidVIA, i+1, err)
+				}
+				if p.R != 0 || p.RX != 0 || p.RY != 0 {
+					return fmt.Errorf("%w: row %d: rotated keys aren't supported", ErrInvalidVIA, i+1)
+				}
+				x += p.X
+				y += p.Y
+				if p.W != 0 {
+					w = p.W
+				}
+				if p.H != 0 {
+					h = p.H
+				}
+				if p.A != nil {
+					align = *p.A
+				}
+				decal = p.D
+				continue
+			}
+
+			k, ok, err := viaKey(s, align, def.Matrix.Rows, def.Matrix.Cols)
+			switch {
+			case decal:
+			case err != nil:
+				return fmt.Errorf("%w: key at %g,%g: %s", ErrInvalidVIA, x, y, err)
+			case ok:
+				if seen[k.Pos] {
+					return fmt.Errorf("%w: key at %g,%g: %d,%d is used more than once",
+						ErrInvalidVIA, x, y, k.Pos.Row, k.Pos.Col)
+				}
+				if w < minKeySize || h < minKeySize {
+					return fmt.Errorf("%w: key at %g,%g: %gx%g is smaller than %gx%g",
+						ErrInvalidVIA, x, y, w, h, minKeySize, minKeySize)
+				}
+				seen[k.Pos] = true
+				k.X, k.Y, k.W, k.H = x, y, w, h
+				keys = append(keys, k)
+			}
+
+			x += w
+			w, h, decal = 1, 1, false
+		}
+		y++
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: no keys", ErrInvalidVIA)
+	}
+
+	// KLE offsets can be negative so the top left key isn't always at 0,0.
+	minX, minY := keys[0].X, keys[0].Y
+	for _, k := range keys {
+		minX, minY = math.Min(minX, k.X), math.Min(minY, k.Y)
+	}
+	for i := range keys {
+		keys[i].X -= minX
+		keys[i].Y -= minY
+	}
+
+	l.Name, l.Description, l.Keys = def.Name, def.Name, keys
+	return nil
+}
+
+// viaKey returns the key described by the KLE legends.  It's not ok for
+// encoders and keys that are only in other layout option choices.
+func viaKey(s string, align, rows, cols int) (k Key, ok bool, err error) {
+	legends := kleLegends(s, align)
+	if legends[kleCenter] == "e" {
+		return
+	}
+	if opt := legends[kleBottomRight]; opt != "" {
+		_, choice, err := parsePair(opt)
+		if err != nil {
+			return k, false, err
+		}
+		if choice != 0 {
+			return k, false, nil
+		}
+	}
+
+	if legends[kleTopLeft] == "" {
+		return k, false, errors.New("no row,col top left legend")
+	}
+	k.Pos.Row, k.Pos.Col, err = parsePair(legends[kleTopLeft])
+	if err != nil {
+		return
+	}
+	if k.Pos.Row < 0 || k.Pos.Row >= rows || k.Pos.Col < 0 || k.Pos.Col >= cols {
+		err = fmt.Errorf("%d,%d is outside the %dx%d matrix", k.Pos.Row, k.Pos.Col, rows, cols)
+		return
+	}
+
+	k.Label = legends[kleFrontCenter]
+	if k.Label == "" {
+		k.Label = fmt.Sprintf("R%d C%d", k.Pos.Row, k.Pos.Col)
+	}
+	return k, true, nil
+}
+
+// MarshalVIA encodes the layout as a VIA definition.  The keymap rows can
+// also be pasted into keyboard-layout-editor.com as raw data.  Each key has
+// its "row,col" matrix position as the top left legend and its label as the
+// front legend.
+func (l Layout) MarshalVIA() ([]byte, error) {
+	def := VIADefinition{
+		Name:      l.Description,
+		VendorID:  fmt.Sprintf("0x%04X", uint16(blusb.VID)),
+		ProductID: fmt.Sprintf("0x%04X", uint16(blusb.PID)),
+	}
+	def.Matrix.Rows, def.Matrix.Cols = blusb.MatrixRows, blusb.MatrixCols
+
+	// Group the keys into rows by their top edge.
+	rows := map[float64][]Key{}
+	var ys []float64
+	for _, k := range l.Keys {
+		if _, ok := rows[k.Y]; !ok {
+			ys = append(ys, k.Y)
+		}
+		rows[k.Y] = append(rows[k.Y], k)
+	}
+	sort.Float64s(ys)
+
+	var y float64
+	for _, ky := range ys {
+		keys := rows[ky]
+		sort.SliceStable(keys, func(i, j int) bool { return keys[i].X < keys[j].X })
+
+		var row []interface{}
+		var x float64
+		for i, k := range keys {
+			var p kleProps
+			if i == 0 {
+				p.Y = ky - y
+			}
+			p.X = k.X - x
+			if k.W != 1 {
+				p.W = k.W
+			}
+			if k.H != 1 {
+				p.H = k.H
+			}
+			if p != (kleProps{}) {
+				row = append(row, p)
+			}
+
+			legends := make([]string, kleFrontCenterIndex+1)
+			legends[0] = fmt.Sprintf("%d,%d", k.Pos.Row, k.Pos.Col)
+			legends[kleFrontCenterIndex] = k.Label
+			row = append(row, strings.Join(legends, "\n"))
+			x = k.X + k.W
+		}
+		y = ky + 1
+
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		def.Layouts.Keymap = append(def.Layouts.Keymap, data)
+	}
+
+	data, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+)
+
+func TestVIARoundTrip(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			want, _ := Get(name)
+			data, err := want.MarshalVIA()
+			if err != nil {
+				t.Fatalf("MarshalVIA: %s", err)
+			}
+
+			var got Layout
+			if err := got.UnmarshalVIA(data); err != nil {
+				t.Fatalf("UnmarshalVIA: %s", err)
+			}
+			if got.Name != want.Description || got.Description != want.Description {
+				t.Errorf("name is %q and description %q, want %q", got.Name, got.Description, want.Description)
+			}
+
+			// Keys are written in rows from top to bottom and left to
+			// right, which is also the built-in order.
+			if !reflect.DeepEqual(got.Keys, want.Keys) {
+				t.Errorf("keys differ:\n%v\nwant\n%v", got.Keys, want.Keys)
+			}
+		})
+	}
+}
+
+func TestUnmarshalVIA(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Key
+	}{
+		{
+			name: "definition",
+			data: `{"name": "Test", "matrix": {"rows": 2, "cols": 2},
+				"layouts": {"keymap": [["0,0", {"w": 2}, "0,1"], [{"h": 2}, "1,1\n\n\n\nBig"]]}}`,
+			want: []Key{
+				{Label: "R0 C0", X: 0, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+				{Label: "R0 C1", X: 1, Y: 0, W: 2, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+				{Label: "Big", X: 0, Y: 1, W: 1, H: 2, Pos: blusb.MatrixPos{Row: 1, Col: 1}},
+			},
+		},
+		{
+			name: "bare KLE with metadata",
+			data: `[{"name": "Test"}, ["0,0"]]`,
+			want: []Key{{Label: "R0 C0", W: 1, H: 1}},
+		},
+		{
+			name: "negative x offset",
+			data: `[["0,1"], [{"x": -1}, "0,0"]]`,
+			want: []Key{
+				{Label: "R0 C1", X: 1, Y: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 1}},
+				{Label: "R0 C0", X: 0, Y: 1, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 0}},
+			},
+		},
+		{
+			name: "negative y offset",
+			data: `[[{"y": -0.5}, "0,0"]]`,
+			want: []Key{{Label: "R0 C0", W: 1, H: 1}},
+		},
+		{
+			name: "decals, encoders and other choices skipped",
+			data: `[[{"d": true}, "", "0,0\n\n\n\n\n\n\n\n\ne", "0,1\n\n\n0,1", "0,2\n\n\n0,0"]]`,
+			want: []Key{{Label: "R0 C2", X: 0, W: 1, H: 1, Pos: blusb.MatrixPos{Row: 0, Col: 2}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var l Layout
+			if err := l.UnmarshalVIA([]byte(test.data)); err != nil {
+				t.Fatalf("UnmarshalVIA: %s", err)
+			}
+			if !reflect.DeepEqual(l.Keys, test.want) {
+				t.Errorf("keys are\n%v\nwant\n%v", l.Keys, test.want)
+			}
+
+			// Every layout that's accepted can be drawn.
+			l.Render(blusb.Layer{}, nil)
+		})
+	}
+}
+
+func TestUnmarshalVIAErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not JSON", `via`},
+		{"bad row", `[[], {"x": 1}, ["0,0"]]`},
+		{"bad properties", `[[{"x": "1"}, "0,0"]]`},
+		{"no keys", `[[]]`},
+		{"no legend", `[[""]]`},
+		{"bad legend", `[["a,b"]]`},
+		{"outside matrix", `[["8,0"]]`},
+		{"outside definition matrix", `{"matrix": {"rows": 1, "cols": 1}, "layouts": {"keymap": [["0,1"]]}}`},
+		{"matrix too large", `{"matrix": {"rows": 9, "cols": 20}, "layouts": {"keymap": [["0,0"]]}}`},
+		{"duplicate position", `[["0,0", "0,0"]]`},
+		{"rotated", `[[{"r": 15}, "0,0"]]`},
+		{"tiny width", `[[{"w": 0.05}, "0,0"]]`},
+		{"negative width", `[[{"w": -1}, "0,0"]]`},
+		{"tiny height", `[[{"h": 0.25}, "0,0"]]`},
+	}
+
+	for _, test := range tests {
+		var l Layout
+		if err := l.UnmarshalVIA([]byte(test.data)); !errors.Is(err, ErrInvalidVIA) {
+			t.Errorf("%s: error %v, want %v", test.name, err, ErrInvalidVIA)
+		}
+	}
+}
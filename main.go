@@ -6,249 +6,239 @@ package main
 
 import (
 	"context"
-	"encoding"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
-	"time"
+	"text/tabwriter"
 
 	"github.com/ebarkie/goblusb/internal/blusb"
+	"github.com/ebarkie/goblusb/internal/layout"
 )
 
-const ok = "OK"
+// Exit codes
+const (
+	exitOK          = 0
+	exitFailure     = 1 // Anything not classified below
+	exitUsage       = 2 // Invalid command line
+	exitNotFound    = 3 // No matching controller found
+	exitInput       = 4 // Invalid input file
+	exitDevice      = 5 // Communicating with the controller failed
+	exitVerify      = 6 // Read back didn't match what was set
+	exitPartial     = 7 // Some controllers failed with -all
+	exitInterrupted = 8 // Interrupted before finishing
+)
 
-type uints struct {
-	Want int
-	S    []uint
+var exitCodes = []struct {
+	code int
+	desc string
+}{
+	{exitFailure, "failure"},
+	{exitUsage, "invalid command line"},
+	{exitNotFound, "controller not found"},
+	{exitInput, "invalid input file"},
+	{exitDevice, "controller communication failed"},
+	{exitVerify, "read back didn't match what was set (with -verify)"},
+	{exitPartial, "some controllers failed (with -all)"},
+	{exitInterrupted, "interrupted"},
 }
 
-func (u uints) String() string {
-	s := make([]string, len(u.S))
-	for i := range u.S {
-		s[i] = strconv.FormatUint(uint64(u.S[i]), 10)
-	}
-	return strings.Join(s, ",")
+// cmdError is an error with the exit code for its class of failure.
+type cmdError struct {
+	code int
+	err  error
 }
 
-func (u *uints) Set(value string) error {
-	for _, s := range strings.Split(value, ",") {
-		i, err := strconv.ParseUint(s, 10, 32)
-		if err != nil {
-			return err
-		}
-		u.S = append(u.S, uint(i))
-	}
-
-	if len(u.S) != u.Want {
-		return fmt.Errorf("want %d values but got %d", u.Want, len(u.S))
-	}
+func (e *cmdError) Error() string { return e.err.Error() }
+func (e *cmdError) Unwrap() error { return e.err }
 
-	return nil
+func failure(code int, err error) error {
+	return &cmdError{code: code, err: err}
 }
 
-func writeTextFile(v encoding.TextMarshaler, filename string) error {
-	text, err := v.MarshalText()
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(filename, text, 0644)
+func usageErrorf(format string, a ...interface{}) error {
+	return failure(exitUsage, fmt.Errorf(format, a...))
 }
 
-func main() {
-	check := flag.Bool("check", false, "don't actually set anything")
-	debug := flag.Bool("debug", false, "enable extra debug output")
-
-	monitorMatrix := flag.Bool("monitor-matrix", false, "monitor for key presses")
-	updateFirmware := flag.String("update-firmware", "", "update firmware")
+// exitCode returns the exit code for the error.
+func exitCode(err error) int {
+	var cerr *cmdError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, blusb.ErrVerify):
+		return exitVerify
+	case errors.Is(err, blusb.ErrControllerNotFound), errors.Is(err, blusb.ErrWaitTimeout):
+		return exitNotFound
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.As(err, &cerr):
+		return cerr.code
+	}
+
+	return exitFailure
+}
 
-	version := flag.Bool("version", false, "firmware version")
+// Global options
+var (
+	check  bool
+	verify bool
+	sel    blusb.Selector
+	lay    *layout.Layout
+	output = outputText
+)
 
-	getBright := flag.Bool("get-brightness", false, "get usb and bt brightness")
-	getDebounce := flag.Bool("get-debounce", false, "get debounce duration")
-	getLayers := flag.Bool("get-layers", false, "get layers")
-	getMacros := flag.Bool("get-macros", false, "get macro keys")
-	to := flag.String("to", "", "write to file")
+// Structured output document
+var doc report
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] command [subcommand] [args]\n\nCommands:\n", os.Args[0])
+	printCommands(out, commands)
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "\nExit codes:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, e := range exitCodes {
+		fmt.Fprintf(w, "  %d\t%s\n", e.code, e.desc)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nRun '%s help command' for more about a command.\n", os.Args[0])
+}
 
-	setBright := uints{Want: 2}
-	flag.Var(&setBright, "set-brightness", "set usb,bt brightness")
-	setDebounce := flag.Duration("set-debounce", 0, "set debounce duration")
-	setLayers := flag.String("set-layers", "", "set layers from file")
-	setMacros := flag.String("set-macros", "", "set macro keys fom file")
+func main() {
+	os.Exit(run())
+}
 
+func run() int {
+	flag.Usage = usage
+	flag.BoolVar(&check, "check", false, "don't actually set anything")
+	flag.BoolVar(&verify, "verify", false, "read back and compare after setting")
+	debug := flag.Bool("debug", false, "enable extra debug output")
+	emulate := flag.Bool("emulate", false, "use an in-memory controller emulator")
+	backend := flag.String("backend", "", "device backend ("+strings.Join(backendNames(), ", ")+")")
+	flag.Var(&blusb.BootVID, "boot-vid", "bootloader USB vendor ID")
+	flag.Var(&blusb.BootPID, "boot-pid", "bootloader USB product ID; the default is unconfirmed")
+	flag.Func("device", "select controller by bus:address, port=path, or serial=number", func(s string) (err error) {
+		sel, err = blusb.ParseSelector(s)
+		return
+	})
+	flag.Func("output", "output format ("+strings.Join(outputFormats, ", ")+")", outputFlag)
+	layoutName := flag.String("layout", "", "physical layout ("+strings.Join(layout.Names(), ", ")+", or a VIA definition file)")
 	flag.Parse()
 
 	if *debug {
 		blusb.Debug.SetOutput(os.Stderr)
 	}
 
-	c, err := blusb.Open()
-	if err != nil {
-		fmt.Printf("Open device error: %s\n", err)
-		return
-	}
-	defer c.Close()
-	fmt.Printf("Blusb Controller - %s\n\n", c)
-
-	if *check {
-		c.SkipSets = true
-	}
-
-	// Exclusive operations
-	if *monitorMatrix {
-		// Monitoring too quickly after pressing enter to start this
-		// command bombards standard input with repeating carriage
-		// returns so sleep a little bit.
-		time.Sleep(500 * time.Millisecond)
-
-		// XXX Safeguard in case something goes wrong and another
-		// keyboard isn't handy.  Maybe remove this in the future.
-		dur := 30 * time.Second
-		fmt.Printf("Monitoring matrix for up to %s.  Press the same key twice in a row to exit sooner.\n\n", dur)
-
-		ctx, cancel := context.WithTimeout(context.Background(), dur)
-		defer cancel()
-		var prevPos blusb.MatrixPos
-		for pos := range c.MonitorMatrix(ctx) {
-			fmt.Println(pos)
-
-			if pos == prevPos {
-				return
+	if *layoutName != "" {
+		l, err := loadLayout(*layoutName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			if errors.Is(err, layout.ErrInvalidVIA) {
+				return exitInput
 			}
-			prevPos = pos
+			return exitUsage
 		}
+		lay = &l
 	}
-
-	if *updateFirmware != "" {
-		fmt.Printf("Flashing firmware: %s\n", *updateFirmware)
-		if err := c.UpdateFirmware(*updateFirmware); err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println(ok)
+	if *backend != "" {
+		b, ok := blusb.Backends[*backend]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown backend: %s\n", *backend)
+			return exitUsage
 		}
-		return
+		blusb.DefaultBackend = b
 	}
-
-	// Combinable operations
-	if *version {
-		maj, min, err := c.GetVersion()
-		if err != nil {
-			fmt.Printf("Get version error: %s\n", err)
-			return
-		}
-		fmt.Printf("Version %d.%d\n", maj, min)
+	if *emulate {
+		blusb.DefaultBackend = blusb.NewEmulator()
 	}
 
-	if *getBright {
-		usb, bt, err := c.GetBrightness()
-		if err != nil {
-			fmt.Printf("Get brightness error: %s\n", err)
-			return
-		}
-		fmt.Println("Brightness")
-		fmt.Printf("\tUSB is %d/255\n", usb)
-		fmt.Printf("\tBluetooth is %d/255\n", bt)
-	}
+	// Paged writes stop cleanly between pages when interrupted.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	if *getDebounce {
-		db, err := c.GetDebounce()
-		if err != nil {
-			fmt.Printf("Get debounce error: %s\n", err)
-			return
-		}
-		fmt.Printf("Debounce time is %s\n", db)
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "help" {
+		return help(args[1:])
 	}
 
-	if *getLayers {
-		layers, err := c.GetLayers()
-		if err != nil {
-			fmt.Printf("Get layers error: %s\n", err)
-			return
-		}
-		fmt.Printf("%s", layers)
-
-		if *to != "" {
-			if err := writeTextFile(layers, *to); err != nil {
-				fmt.Printf("Save layers error: %s\n", err)
-				return
-			}
-		}
+	err := dispatch(ctx, commands, nil, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err == nil && structured() {
+		err = doc.write(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 	}
 
-	if *getMacros {
-		macros, err := c.GetMacros()
-		if err != nil {
-			fmt.Printf("Get macros error: %s\n", err)
-			return
-		}
-		fmt.Printf("Macro key table:\n\n%s\n", macros)
+	return exitCode(err)
+}
 
-		if *to != "" {
-			if err := writeTextFile(macros, *to); err != nil {
-				fmt.Printf("Save macros error: %s\n", err)
-			}
-		}
+// help prints help for the command named by args.
+func help(args []string) int {
+	if len(args) == 0 {
+		flag.CommandLine.SetOutput(os.Stdout)
+		usage()
+		return exitOK
 	}
 
-	if len(setBright.S) > 0 {
-		fmt.Println("Setting brightness")
-		fmt.Printf("\tUSB to %d/255\n", setBright.S[0])
-		fmt.Printf("\tBluetooth to %d/255\n", setBright.S[1])
-		if err := c.SetBrightness(setBright.S[0], setBright.S[1]); err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println(ok)
+	cmds := commands
+	for i, name := range args {
+		c := findCommand(cmds, name)
+		if c == nil {
+			fmt.Fprintf(os.Stderr, "Unknown command: %s\n", strings.Join(args[:i+1], " "))
+			return exitUsage
 		}
-	}
-
-	if *setDebounce > 0 {
-		fmt.Printf("Setting debounce to %s\n", *setDebounce)
-		if err := c.SetDebounce(*setDebounce); err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println(ok)
+		if len(c.subs) == 0 || i == len(args)-1 {
+			fs, _ := c.flagSet(args[:i+1])
+			fs.SetOutput(os.Stdout)
+			fs.Usage()
+			break
 		}
+		cmds = c.subs
 	}
 
-	if *setLayers != "" {
-		text, err := os.ReadFile(*setLayers)
-		if err != nil {
-			fmt.Printf("Set layers parse error: %s\n", err)
-			return
-		}
+	return exitOK
+}
 
-		var layers blusb.Layers
-		if err := layers.UnmarshalText(text); err != nil {
-			fmt.Printf("Set layers parse error: %s\n", err)
-			return
-		}
-		fmt.Printf("Setting layers to:\n\n%s", layers)
-		if err := c.SetLayers(layers); err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println(ok)
+// dispatch finds the command named by args and runs it.
+func dispatch(ctx context.Context, cmds []*command, path []string, args []string) error {
+	if len(args) == 0 {
+		if path == nil {
+			return usageErrorf("missing command, run '%s help' for a list", os.Args[0])
 		}
+		return usageErrorf("missing subcommand, run '%s help %s' for a list",
+			os.Args[0], strings.Join(path, " "))
 	}
 
-	if *setMacros != "" {
-		text, err := os.ReadFile(*setMacros)
-		if err != nil {
-			fmt.Printf("Set macros parse error: %s\n", err)
-			return
-		}
+	c := findCommand(cmds, args[0])
+	if c == nil {
+		return usageErrorf("unknown command: %s", strings.Join(append(path, args[0]), " "))
+	}
+	path = append(path, c.name)
+	if len(c.subs) > 0 {
+		return dispatch(ctx, c.subs, path, args[1:])
+	}
+	if structured() && !c.structured {
+		return usageErrorf("%s doesn't support -output %s", strings.Join(path, " "), output)
+	}
 
-		var macros blusb.Macros
-		if err := macros.UnmarshalText(text); err != nil {
-			fmt.Printf("Set macros parse error: %s\n", err)
-			return
-		}
-		fmt.Printf("Setting macros to:\n\n%s\n", macros)
-		if err := c.SetMacros(macros); err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println(ok)
+	fs, run := c.flagSet(path)
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
 		}
+		return failure(exitUsage, err)
 	}
+	if n := fs.NArg(); n < c.minArgs || n > c.maxArgs {
+		fs.Usage()
+		return usageErrorf("%s: wrong number of arguments", strings.Join(path, " "))
+	}
+
+	return run(ctx, fs.Args())
 }
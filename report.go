@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputYAML}
+
+// controllerReport is an attached controller in a report.
+type controllerReport struct {
+	Bus     int    `json:"bus" yaml:"bus"`
+	Address int    `json:"address" yaml:"address"`
+	Port    string `json:"port,omitempty" yaml:"port,omitempty"`
+	Serial  string `json:"serial,omitempty" yaml:"serial,omitempty"`
+	Mode    string `json:"mode" yaml:"mode"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// report is the structured output document.  Commands fill in what they get
+// and it's written once the command finishes.
+type report struct {
+	Device      string             `json:"device,omitempty" yaml:"device,omitempty"`
+	Controllers []controllerReport `json:"controllers,omitempty" yaml:"controllers,omitempty"`
+	Version     string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Brightness  *blusb.Brightness  `json:"brightness,omitempty" yaml:"brightness,omitempty"`
+	Debounce    string             `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+	Layers      blusb.Layers       `json:"layers,omitempty" yaml:"layers,omitempty"`
+	Macros      *blusb.Macros      `json:"macros,omitempty" yaml:"macros,omitempty"`
+}
+
+// structured indicates if output is a structured document instead of text.
+func structured() bool {
+	return output != outputText
+}
+
+// fileFormat returns the structured format for the file extension, or an
+// empty string if it isn't one.
+func fileFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return outputJSON
+	case ".yaml", ".yml":
+		return outputYAML
+	}
+
+	return ""
+}
+
+// marshal encodes v in the structured format.
+func marshal(format string, v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+	case outputYAML:
+		enc := yaml.NewEncoder(buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unmarshal decodes text in the structured format into v.
+func unmarshal(format string, text []byte, v interface{}) error {
+	switch format {
+	case outputJSON:
+		return json.Unmarshal(text, v)
+	case outputYAML:
+		return yaml.Unmarshal(text, v)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+// write encodes the report in the output format.
+func (r report) write(w io.Writer) error {
+	b, err := marshal(output, r)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// outputFlag validates the -output flag value.
+func outputFlag(s string) error {
+	for _, f := range outputFormats {
+		if s == f {
+			output = s
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of %s", strings.Join(outputFormats, ", "))
+}
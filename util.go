@@ -0,0 +1,309 @@
+// Copyright (c) 2020 Eric Barkie. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ebarkie/goblusb/internal/blusb"
+	"github.com/ebarkie/goblusb/internal/layout"
+)
+
+const ok = "OK"
+
+type uints struct {
+	Want int
+	S    []uint
+}
+
+func (u uints) String() string {
+	s := make([]string, len(u.S))
+	for i := range u.S {
+		s[i] = strconv.FormatUint(uint64(u.S[i]), 10)
+	}
+	return strings.Join(s, ",")
+}
+
+func (u *uints) Set(value string) error {
+	for _, s := range strings.Split(value, ",") {
+		i, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return err
+		}
+		u.S = append(u.S, uint(i))
+	}
+
+	if len(u.S) != u.Want {
+		return fmt.Errorf("want %d values but got %d", u.Want, len(u.S))
+	}
+
+	return nil
+}
+
+func writeTextFile(v encoding.TextMarshaler, filename string) error {
+	text, err := v.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, text, 0644)
+}
+
+// writeFile writes v to a file in the structured format.
+func writeFile(format string, v interface{}, filename string) error {
+	text, err := marshal(format, v)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, text, 0644)
+}
+
+func readLayers(filename string) (blusb.Layers, error) {
+	text, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers blusb.Layers
+	switch {
+	case layout.IsQMK(text):
+		if lay == nil {
+			return nil, errors.New("QMK keymaps require -layout")
+		}
+		layers, err = lay.UnmarshalQMK(text)
+	case fileFormat(filename) != "":
+		err = unmarshal(fileFormat(filename), text, &layers)
+	case blusb.IsKeymap(text):
+		err = layers.UnmarshalKeymap(text)
+	default:
+		err = layers.UnmarshalText(text)
+	}
+	return layers, err
+}
+
+// loadLayout returns the built-in layout by name, or reads a VIA definition
+// file if there isn't one.
+func loadLayout(name string) (layout.Layout, error) {
+	l, err := layout.Get(name)
+	if err == nil {
+		return l, nil
+	}
+
+	text, rerr := os.ReadFile(name)
+	if rerr != nil {
+		if errors.Is(rerr, os.ErrNotExist) {
+			return l, err
+		}
+		return l, rerr
+	}
+	err = l.UnmarshalVIA(text)
+	return l, err
+}
+
+// writeQMK writes the layers to a file as a QMK keymap.json document.
+func writeQMK(layers blusb.Layers, lay *layout.Layout, filename string) error {
+	if lay == nil {
+		return errors.New("QMK keymaps require -layout")
+	}
+
+	text, err := lay.MarshalQMK(layers)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, text, 0644)
+}
+
+// renderLayers draws each layer on the physical layout.  Keys that differ
+// from the first layer are highlighted.
+func renderLayers(lay layout.Layout, layers blusb.Layers) string {
+	var b strings.Builder
+	for i, l := range layers {
+		var base *blusb.Layer
+		if i > 0 {
+			base = &layers[0]
+		}
+		fmt.Fprintf(&b, "Layer %d/%d\n\n%s\n", i+1, len(layers), lay.Render(l, base))
+	}
+
+	return b.String()
+}
+
+// writeLayers writes the layers to a file.  The format is picked by
+// extension: keymap for .keymap, JSON or YAML for .json and .yaml, a drawing
+// on the physical layout for .svg and .html, and CSV otherwise.
+func writeLayers(layers blusb.Layers, lay *layout.Layout, filename string) error {
+	if f := fileFormat(filename); f != "" {
+		return writeFile(f, layers, filename)
+	}
+
+	var text []byte
+	switch ext := filepath.Ext(filename); ext {
+	case ".keymap":
+		var err error
+		text, err = layers.MarshalKeymap()
+		if err != nil {
+			return err
+		}
+	case ".svg", ".html":
+		if lay == nil {
+			return fmt.Errorf("%s requires -layout", ext)
+		}
+		if ext == ".svg" {
+			text = lay.SVG(layers)
+		} else {
+			text = lay.HTML(layers)
+		}
+	default:
+		return writeTextFile(layers, filename)
+	}
+
+	return os.WriteFile(filename, text, 0644)
+}
+
+func readConfig(filename string) (s blusb.Settings, err error) {
+	text, err := os.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	if f := fileFormat(filename); f != "" {
+		err = unmarshal(f, text, &s)
+	} else {
+		err = s.UnmarshalConfig(text)
+	}
+	return
+}
+
+// writeConfig writes the settings to a file as JSON or YAML for .json and
+// .yaml extensions, and a config document otherwise.
+func writeConfig(s blusb.Settings, filename string) error {
+	if f := fileFormat(filename); f != "" {
+		return writeFile(f, s, filename)
+	}
+
+	text, err := s.MarshalConfig()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, text, 0644)
+}
+
+func readBackup(filename string) (b blusb.Backup, err error) {
+	text, err := os.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	err = b.UnmarshalText(text)
+	return
+}
+
+func readMacros(filename string) (macros blusb.Macros, err error) {
+	text, err := os.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	if f := fileFormat(filename); f != "" {
+		err = unmarshal(f, text, &macros)
+	} else {
+		err = macros.UnmarshalText(text)
+	}
+	return
+}
+
+// writeMacros writes the macros to a file as JSON or YAML for .json and
+// .yaml extensions, and CSV otherwise.
+func writeMacros(macros blusb.Macros, filename string) error {
+	if f := fileFormat(filename); f != "" {
+		return writeFile(f, macros, filename)
+	}
+
+	return writeTextFile(macros, filename)
+}
+
+// printDiff prints the changes, naming keys from the physical layout if
+// there is one.
+func printDiff(d blusb.Diff, lay *layout.Layout) {
+	if d.IsZero() {
+		fmt.Println("No changes")
+		return
+	}
+
+	var name func(blusb.MatrixPos) string
+	if lay != nil {
+		name = func(pos blusb.MatrixPos) string {
+			k, _ := lay.Key(pos)
+			return k.Label
+		}
+	}
+	fmt.Print(d.Format(name))
+}
+
+// backendNames returns the sorted names of the available device backends.
+func backendNames() []string {
+	names := make([]string, 0, len(blusb.Backends))
+	for name := range blusb.Backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// progressBar returns a progress function that draws a progress bar on
+// standard output.
+func progressBar() blusb.ProgressFunc {
+	const width = 40
+
+	return func(p blusb.Progress) {
+		done := width
+		if p.Pages > 0 {
+			done = width * p.Page / p.Pages
+		}
+		fmt.Printf("\r[%s%s] %d/%d pages %d/%d bytes",
+			strings.Repeat("#", done), strings.Repeat(" ", width-done),
+			p.Page, p.Pages, p.Bytes, p.TotalBytes)
+		if p.Done() {
+			fmt.Println()
+		}
+	}
+}
+
+// setCount returns the number of settings that will be set.
+func setCount(s blusb.Settings) (n int) {
+	for _, set := range []bool{s.Brightness != nil, s.Debounce > 0, s.Macros != nil, s.Layers != nil} {
+		if set {
+			n++
+		}
+	}
+
+	return
+}
+
+// printSettings prints a summary of the settings that will be set.
+func printSettings(s blusb.Settings) {
+	if s.Brightness != nil {
+		fmt.Printf("\tBrightness to %d/255 USB, %d/255 Bluetooth\n", s.Brightness.USB, s.Brightness.Bluetooth)
+	}
+	if s.Debounce > 0 {
+		fmt.Printf("\tDebounce to %s\n", s.Debounce)
+	}
+	if s.Macros != nil {
+		fmt.Println("\tMacros")
+	}
+	if s.Layers != nil {
+		fmt.Printf("\t%d layers\n", len(s.Layers))
+	}
+}